@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ---------- Accessibility output ----------
+// a11yOutputter는 스크린 리더가 그대로 읽을 수 있도록
+// 이모지, 기호, 박스 문자 없이 완전한 문장으로 출력한다.
+type a11yOutputter struct {
+	opts Options
+}
+
+func (o a11yOutputter) Output(r Report) error {
+	fmt.Println(a11ySummary(r, o.opts, r.Time))
+	return nil
+}
+
+// a11ySummary는 --lang 의 (ko,en 이면 첫) 언어 한 가지로만 읽는다. 라벨은 messages 문구를 쓴다.
+func a11ySummary(r Report, opts Options, now time.Time) string {
+	t := a11yText{en: opts.lang() == "en"}
+	var b strings.Builder
+	if !opts.NoHeader {
+		fmt.Fprintf(&b, "%s, %s. ", r.Location.Name, t.pick(now.Format("1월 2일 15:04 한국 표준시"), now.Format("January 2, 15:04 Korea Standard Time")))
+		if r.Location.Approximate {
+			b.WriteString(t.sentence(t.label("approximate")) + ". ")
+		}
+	}
+
+	if r.WeatherErr == nil {
+		a11yWeather(&b, r.Weather, opts, t)
+	} else {
+		b.WriteString(t.sentence(t.label("no_weather")) + ". ")
+	}
+	if r.AirErr == nil {
+		a11yAir(&b, r.Air, t)
+	} else {
+		b.WriteString(t.sentence(t.label("no_air")) + ".")
+	}
+
+	if opts.Moon {
+		i, illum := moonPhase(now)
+		fmt.Fprintf(&b, " %s %s, %s.", t.sentence(t.label("moon")), t.pick(moonNamesKR[i], moonNamesEN[i]),
+			t.pick(fmt.Sprintf("밝기 %.0f퍼센트", illum*100), fmt.Sprintf("%.0f percent illuminated", illum*100)))
+	}
+	if opts.Advice && r.WeatherErr == nil && r.AirErr == nil {
+		single := opts
+		single.DualLang = false // 스크린 리더에는 한 언어만 읽힌다.
+		for _, tip := range advice(r.Weather, r.Air, single) {
+			fmt.Fprintf(&b, " %s.", tip)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// a11yText는 a11y 문장을 한 언어로 고른다.
+type a11yText struct {
+	en bool
+}
+
+func (t a11yText) pick(ko, en string) string {
+	if t.en {
+		return en
+	}
+	return ko
+}
+
+func (t a11yText) label(key string) string {
+	return t.pick(msg(key))
+}
+
+// sentence는 문장 첫 글자를 대문자로 한다. (한국어는 그대로)
+func (t a11yText) sentence(s string) string {
+	if !t.en || s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func a11yWeather(b *strings.Builder, w Current, opts Options, t a11yText) {
+	p := opts.Precision
+
+	fmt.Fprintf(b, "%s. ", t.pick(conditionKR(w.WeatherCode), conditionEN(w.WeatherCode)))
+	temp, feels := p.format("temp", w.Temperature2m), p.format("temp", w.ApparentTemperature)
+	scaleKR, scaleEN := "섭씨", "Celsius"
+	if opts.imperial() {
+		scaleKR, scaleEN = "화씨", "Fahrenheit"
+	}
+	fmt.Fprintf(b, "%s %s, %s %s. ", t.sentence(t.label("temp")),
+		t.pick(fmt.Sprintf("%s %s도", scaleKR, temp), fmt.Sprintf("%s degrees %s", temp, scaleEN)),
+		t.label("feels"), t.pick(feels+"도", feels))
+	if w.PrecipProbability != nil {
+		v := p.format("precip", opts.bucketPrecip(*w.PrecipProbability))
+		fmt.Fprintf(b, "%s %s. ", t.sentence(t.label("precip_prob")), t.pick(v+"퍼센트", v+" percent"))
+	}
+	if w.Visibility != nil {
+		v, kr, en := p.format("visibility", metersToKm(*w.Visibility)), "킬로미터", " kilometers"
+		if opts.imperial() {
+			v, kr, en = p.format("visibility", metersToMiles(*w.Visibility)), "마일", " miles"
+		}
+		fmt.Fprintf(b, "%s %s. ", t.sentence(t.label("visibility")), t.pick(v+kr, v+en))
+	}
+	if w.WindSpeed != nil {
+		force, name := beaufort(windKmh(*w.WindSpeed, opts))
+		v, kr, en := p.format("wind", *w.WindSpeed), "킬로미터", " kilometers per hour"
+		if opts.imperial() {
+			kr, en = "마일", " miles per hour"
+		}
+		fmt.Fprintf(b, "%s %s, %s. ", t.sentence(t.label("wind")), t.pick("시속 "+v+kr, v+en), t.pick(name, beaufortNameEN(force)))
+	}
+}
+
+func a11yAir(b *strings.Builder, aq AirQualityCurrent, t a11yText) {
+	aqi, estimated := aq.usAQI()
+	index := fmt.Sprintf("AQI %d", aqi)
+	if estimated {
+		index = t.pick("추정 ", "estimated ") + index
+	}
+	fmt.Fprintf(b, "%s %s, %s. ", t.sentence(t.label("air")), t.pick(aqiLabel(aqi), aqiStatusEN(aqi)), index)
+	pm10, _, _ := pm10GradeKR(aq.PM10)
+	pm25, _, _ := pm25GradeKR(aq.PM25)
+	fmt.Fprintf(b, "%s %s, %s %s.", t.sentence(t.label("pm10")), t.pick(pm10, gradeEN(pm10)), t.label("pm25"), t.pick(pm25, gradeEN(pm25)))
+}
+
+// conditionKR은 아이콘 없는 한국어 날씨 이름이다.
+func conditionKR(code int) string {
+	return lookupCode(code).label
+}
+
+func conditionEN(code int) string {
+	return lookupCode(code).en
+}
+
+func aqiStatusEN(aqi int) string {
+	return aqiNamesEN[gradeIndex(float64(aqi), thresholds.AQI)]
+}
+
+// gradeEN은 국내 4단계 등급을 영어로 옮긴다.
+func gradeEN(grade string) string {
+	switch grade {
+	case "좋음":
+		return "good"
+	case "보통":
+		return "moderate"
+	case "나쁨":
+		return "bad"
+	case "매우 나쁨":
+		return "very bad"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"unicode"
+)
+
+func TestA11ySummary(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	fixNow(t, now)
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"ko", "서울, 3월 14일 15:30 한국 표준시. 맑음. 기온 섭씨 12.3도, 체감 11.0도. 강수 확률 20퍼센트. 가시거리 24.0킬로미터. " +
+			"바람 시속 9.4킬로미터, 남실바람. 대기질 보통, AQI 72. 미세먼지(PM10) 보통, 초미세먼지(PM2.5) 보통. 달 위상 보름, 밝기 100퍼센트."},
+		{"en", "서울, March 14, 15:30 Korea Standard Time. Clear. Temperature 12.3 degrees Celsius, feels like 11.0. Precipitation chance 20 percent. " +
+			"Visibility 24.0 kilometers. Wind 9.4 kilometers per hour, Light breeze. Air quality moderate, AQI 72. " +
+			"Fine dust moderate, ultrafine dust moderate. Moon phase full moon, 100 percent illuminated."},
+	}
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Lang, opts.Moon = tt.lang, true
+		if got := a11ySummary(testReport(), opts, now); got != tt.want {
+			t.Errorf("lang=%s\ngot:  %s\nwant: %s", tt.lang, got, tt.want)
+		}
+	}
+}
+
+// --lang=ko,en 이어도 두 언어를 섞어 읽지 않는다. (첫 언어만)
+func TestA11ySummaryMissingData(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	r := testReport()
+	r.Location.Approximate = true
+	r.WeatherErr, r.AirErr = errors.New("timeout"), errors.New("timeout")
+
+	opts := testOptions()
+	opts.DualLang = true
+	want := "서울, 3월 14일 15:30 한국 표준시. IP 기반 추정 위치. 날씨 정보 없음. 대기질 정보 없음."
+	if got := a11ySummary(r, opts, now); got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+
+	opts.Lang, opts.DualLang = "en", false
+	want = "서울, March 14, 15:30 Korea Standard Time. Approximate, IP based. No weather data. No air quality data."
+	if got := a11ySummary(r, opts, now); got != want {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+// 어떤 날씨 코드, 낮밤, 언어에서도 이모지/기호 문자, 변형 선택자, ZWJ 가 섞이지 않는다.
+func TestA11ySummaryNoSymbols(t *testing.T) {
+	day := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	times := []time.Time{day, day.Add(8 * time.Hour)} // 낮, 밤 (일몰 18:35 이후)
+	for code := range wmoCodes {
+		for _, now := range times {
+			for _, lang := range []string{"ko", "en"} {
+				fixNow(t, now)
+				r := testReport()
+				r.Weather.WeatherCode = code
+				opts := testOptions()
+				opts.Lang, opts.Moon, opts.Advice = lang, true, true
+				got := a11ySummary(r, opts, now)
+				for _, c := range got {
+					if unicode.In(c, unicode.So, unicode.Sk, unicode.Cs) || c == 0xFE0F || c == 0x200D {
+						t.Errorf("code=%d lang=%s %s: rune %U in %q", code, lang, now.Format("15:04"), c, got)
+						break
+					}
+				}
+			}
+		}
+	}
+}
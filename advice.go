@@ -0,0 +1,172 @@
+package main
+
+import "fmt"
+
+// ---------- Advice ----------
+// 조언 기준 (체감온도 °C, 강수확률 %, UV 지수, US AQI)
+const (
+	adviceFreezing   = 0.0
+	adviceChilly     = 10.0
+	adviceHot        = 28.0
+	adviceSweltering = 33.0
+	adviceRainProb   = 60
+	adviceUVHigh     = 6.0
+	adviceAQIBad     = 100
+)
+
+type adviceKind int
+
+const (
+	adviceFreezingKind adviceKind = iota
+	adviceChillyKind
+	adviceHotKind
+	adviceSwelteringKind
+	adviceRainKind
+	adviceSnowKind
+	adviceUVKind
+	adviceAirKind
+)
+
+// adviceKinds는 w (섭씨 기준)와 aq로부터 해당하는 조언 종류를 고른다.
+// aqiWarnAt > 0 이면 (--aqi-warn-at) 마스크 조언은 표시 등급과 관계없이 US AQI가 그 이상일 때만 낸다.
+func adviceKinds(w Current, aq AirQualityCurrent, aqiWarnAt int) []adviceKind {
+	var kinds []adviceKind
+
+	switch t := w.ApparentTemperature; {
+	case t <= adviceFreezing:
+		kinds = append(kinds, adviceFreezingKind)
+	case t <= adviceChilly:
+		kinds = append(kinds, adviceChillyKind)
+	case t >= adviceSweltering:
+		kinds = append(kinds, adviceSwelteringKind)
+	case t >= adviceHot:
+		kinds = append(kinds, adviceHotKind)
+	}
+
+	switch {
+	case isSnowCode(w.WeatherCode):
+		kinds = append(kinds, adviceSnowKind)
+	case isRainCode(w.WeatherCode) || rainLikely(w):
+		kinds = append(kinds, adviceRainKind)
+	}
+
+	if w.UVIndex != nil && *w.UVIndex >= adviceUVHigh {
+		kinds = append(kinds, adviceUVKind)
+	}
+
+	if airAdvised(aq, aqiWarnAt) {
+		kinds = append(kinds, adviceAirKind)
+	}
+	return kinds
+}
+
+func airAdvised(aq AirQualityCurrent, aqiWarnAt int) bool {
+	aqi, _ := aq.usAQI()
+	if aqiWarnAt > 0 {
+		return aqi >= aqiWarnAt
+	}
+	pm10, _, _ := pm10GradeKR(aq.PM10)
+	pm25, _, _ := pm25GradeKR(aq.PM25)
+	return aqi > adviceAQIBad || gradeSeverity(pm10) >= 2 || gradeSeverity(pm25) >= 2
+}
+
+// adviceKR은 해당하는 조언을 한국어로 돌려준다. (없으면 nil)
+func adviceKR(w Current, aq AirQualityCurrent, aqiWarnAt int) []string {
+	var tips []string
+	for _, k := range adviceKinds(w, aq, aqiWarnAt) {
+		switch k {
+		case adviceFreezingKind:
+			tips = append(tips, "매우 추워요, 두꺼운 외투와 장갑을 챙기세요")
+		case adviceChillyKind:
+			tips = append(tips, "쌀쌀해요, 겉옷을 챙기세요")
+		case adviceHotKind:
+			tips = append(tips, "더워요, 가벼운 옷차림을 추천해요")
+		case adviceSwelteringKind:
+			tips = append(tips, "무더워요, 물을 자주 마시고 한낮 외출을 피하세요")
+		case adviceRainKind:
+			tips = append(tips, "비 소식이 있어요, 우산을 챙기세요")
+		case adviceSnowKind:
+			tips = append(tips, "눈이 와요, 미끄럼에 주의하세요")
+		case adviceUVKind:
+			tips = append(tips, "자외선이 강하니 선크림을 바르세요")
+		case adviceAirKind:
+			tips = append(tips, "공기가 나빠요, 마스크 착용을 권장해요")
+		}
+	}
+	return tips
+}
+
+func adviceEN(w Current, aq AirQualityCurrent, aqiWarnAt int) []string {
+	var tips []string
+	for _, k := range adviceKinds(w, aq, aqiWarnAt) {
+		switch k {
+		case adviceFreezingKind:
+			tips = append(tips, "Freezing out there, wear a heavy coat and gloves")
+		case adviceChillyKind:
+			tips = append(tips, "Chilly, bring a jacket")
+		case adviceHotKind:
+			tips = append(tips, "Warm, dress lightly")
+		case adviceSwelteringKind:
+			tips = append(tips, "Very hot, stay hydrated and avoid the midday sun")
+		case adviceRainKind:
+			tips = append(tips, "Rain is likely, take an umbrella")
+		case adviceSnowKind:
+			tips = append(tips, "Snowing, watch your step")
+		case adviceUVKind:
+			tips = append(tips, "Strong UV, put on sunscreen")
+		case adviceAirKind:
+			tips = append(tips, "Poor air quality, consider wearing a mask")
+		}
+	}
+	return tips
+}
+
+// advice는 단위를 섭씨로 맞춘 뒤 --lang에 맞는 조언을 돌려준다. --lang=ko,en 이면 "한국어 (English)"다.
+func advice(w Current, aq AirQualityCurrent, opts Options) []string {
+	w = celsiusWeather(w, opts)
+	if opts.DualLang {
+		// 두 목록은 같은 adviceKinds 순서다.
+		tips, en := adviceKR(w, aq, opts.AQIWarnAt), adviceEN(w, aq, opts.AQIWarnAt)
+		for i := range tips {
+			tips[i] = opts.dual(tips[i], en[i])
+		}
+		return tips
+	}
+	if opts.lang() == "en" {
+		return adviceEN(w, aq, opts.AQIWarnAt)
+	}
+	return adviceKR(w, aq, opts.AQIWarnAt)
+}
+
+// celsiusWeather는 --unit=f 로 받은 온도를 섭씨로 되돌린다. (기준값 비교용)
+func celsiusWeather(w Current, opts Options) Current {
+	if opts.imperial() {
+		w.Temperature2m = fahrenheitToCelsius(w.Temperature2m)
+		w.ApparentTemperature = fahrenheitToCelsius(w.ApparentTemperature)
+	}
+	return w
+}
+
+func printAdvice(w Current, aq AirQualityCurrent, opts Options) {
+	for _, tip := range advice(w, aq, opts) {
+		fmt.Printf("%s %s\n", tipPrefix(opts), tip)
+	}
+}
+
+// rainLikely는 강수 확률이 adviceRainProb 이상인지 본다.
+// 확률을 주지 않는 지역이면 지금 내리는 강수량으로 판단한다.
+func rainLikely(w Current) bool {
+	if w.PrecipProbability != nil {
+		return *w.PrecipProbability >= adviceRainProb
+	}
+	return w.Precipitation != nil && *w.Precipitation > 0
+}
+
+// isRainCode, isSnowCode는 wmoCodes의 강수 종류다. (소나기, 어는 비, 뇌우 포함)
+func isRainCode(code int) bool {
+	return lookupCode(code).precip == precipRain
+}
+
+func isSnowCode(code int) bool {
+	return lookupCode(code).precip == precipSnow
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAdviceLang(t *testing.T) {
+	w := Current{ApparentTemperature: 5, WeatherCode: 61}
+	aq := AirQualityCurrent{PM10: 20, PM25: 10, AQIUS: ptr(30)}
+	if n := len(adviceKR(w, aq, 0)); n != 2 {
+		t.Fatalf("fixture gives %d tips, want chilly + rain", n)
+	}
+
+	tests := []struct {
+		lang string
+		dual bool
+		want []string
+	}{
+		{"ko", false, adviceKR(w, aq, 0)},
+		{"en", false, adviceEN(w, aq, 0)},
+		{"ko", true, []string{
+			adviceKR(w, aq, 0)[0] + " (" + adviceEN(w, aq, 0)[0] + ")",
+			adviceKR(w, aq, 0)[1] + " (" + adviceEN(w, aq, 0)[1] + ")",
+		}},
+	}
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.Lang, opts.DualLang = tt.lang, tt.dual
+		if got := advice(w, aq, opts); !slices.Equal(got, tt.want) {
+			t.Errorf("lang=%s dual=%v: %q, want %q", tt.lang, tt.dual, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ---------- air 명령 ----------
+func RunAir(ctx context.Context, city string, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	loc, err := resolveCity(ctx, client, city, opts)
+	if err != nil {
+		return opts.deadlineErr(ctx, err)
+	}
+
+	q := airQuery(opts, opts.AirDetail)
+	if opts.DryRun {
+		fmt.Println("GET " + buildAirURL(endpoints.Air[0], loc.Latitude, loc.Longitude, q))
+		return nil
+	}
+
+	actx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.AirTimeout))
+	defer cancel()
+
+	aq, err := cachedByGrid(opts, "air", loc.Latitude, loc.Longitude, q, func() (AirQualityCurrent, error) {
+		return fetchAirQuality(actx, client, loc.Latitude, loc.Longitude, q)
+	})
+	if err != nil {
+		return opts.deadlineErr(ctx, err)
+	}
+	aq = clampNegativeAir(aq, opts)
+
+	fmt.Printf("%s 대기질\n", loc.Name)
+	if !opts.AirDetail {
+		printAir(aq, opts)
+		return nil
+	}
+
+	printAirDetail(aq, opts)
+	return nil
+}
+
+func printAirDetail(aq AirQualityCurrent, opts Options) {
+	ps := pollutants(aq)
+	sortBySeverity(ps)
+
+	for _, p := range ps {
+		fmt.Printf("%s %s %s %s\n",
+			padRight(p.Label, 18),
+			padLeft(opts.Precision.format(p.Field, p.Value), 8),
+			padRight(p.Unit, 6),
+			p.Grade,
+		)
+	}
+}
+
+// ---------- Pollutants ----------
+type pollutant struct {
+	Field string // Precision 키
+	Label string
+	Value float64
+	Unit  string
+	Grade string
+}
+
+// pollutants는 응답에 있는 오염물질만 골라 등급과 함께 돌려준다.
+func pollutants(aq AirQualityCurrent) []pollutant {
+	pm10, _, _ := pm10GradeKR(aq.PM10)
+	pm25, _, _ := pm25GradeKR(aq.PM25)
+	ps := []pollutant{
+		{"pm10", "미세먼지(PM10)", aq.PM10, "㎍/m³", pm10},
+		{"pm25", "초미세먼지(PM2.5)", aq.PM25, "㎍/m³", pm25},
+	}
+
+	gases := []struct {
+		field, label string
+		v            *float64
+		grade        func(float64) string
+	}{
+		{"o3", "오존(O₃)", aq.Ozone, o3GradeKR},
+		{"no2", "이산화질소(NO₂)", aq.NO2, no2GradeKR},
+		{"so2", "아황산가스(SO₂)", aq.SO2, so2GradeKR},
+		{"co", "일산화탄소(CO)", aq.CO, coGradeKR},
+	}
+	for _, g := range gases {
+		if g.v == nil {
+			continue
+		}
+		ps = append(ps, pollutant{g.field, g.label, *g.v, "㎍/m³", g.grade(*g.v)})
+	}
+	return ps
+}
+
+// sortBySeverity는 등급이 나쁜 순으로 정렬한다. 같은 등급은 원래 순서를 유지한다.
+func sortBySeverity(ps []pollutant) {
+	sort.SliceStable(ps, func(i, j int) bool {
+		return gradeSeverity(ps[i].Grade) > gradeSeverity(ps[j].Grade)
+	})
+}
+
+// topPollutants는 등급이 가장 나쁜 오염물질들이다. (같은 등급이면 모두, 원래 순서)
+// 모두 좋음이면 nil이다.
+func topPollutants(aq AirQualityCurrent) []pollutant {
+	var top []pollutant
+	worst := 0
+	for _, p := range pollutants(aq) {
+		switch s := gradeSeverity(p.Grade); {
+		case s > worst:
+			worst, top = s, []pollutant{p}
+		case s == worst && s > 0:
+			top = append(top, p)
+		}
+	}
+	return top
+}
+
+// topPollutantText는 --top-pollutant 의 "주요 오염물질: 초미세먼지(PM2.5) (나쁨)" 줄이다.
+func topPollutantText(aq AirQualityCurrent) string {
+	top := topPollutants(aq)
+	if len(top) == 0 {
+		return "주요 오염물질: 없음 (모두 좋음)"
+	}
+	labels := make([]string, len(top))
+	for i, p := range top {
+		labels[i] = p.Label
+	}
+	return fmt.Sprintf("주요 오염물질: %s (%s)", strings.Join(labels, ", "), top[0].Grade)
+}
+
+func gradeSeverity(grade string) int {
+	switch grade {
+	case "좋음":
+		return 0
+	case "보통":
+		return 1
+	case "나쁨":
+		return 2
+	case "매우 나쁨":
+		return 3
+	default:
+		return -1
+	}
+}
+
+// ---------- Negative values ----------
+// 모델 오차로 us_aqi -3, pm2_5 -0.2 같은 음수가 올 때가 있다.
+// 등급을 매기기 전에 0으로 자르고, 원값은 Clamped에 남긴다. (--clamp-negative-aqi=false 로 끈다)
+func clampNegativeAir(aq AirQualityCurrent, opts Options) AirQualityCurrent {
+	if !opts.ClampNegativeAQI {
+		return aq
+	}
+	clamped := map[string]float64{}
+	for _, f := range []struct {
+		name string
+		v    *float64
+	}{
+		{"pm10", &aq.PM10},
+		{"pm2_5", &aq.PM25},
+		{"pm2_5_avg_24h", aq.PM25Avg24h},
+	} {
+		if f.v != nil && *f.v < 0 {
+			clamped[f.name], *f.v = *f.v, 0
+		}
+	}
+	for _, f := range []struct {
+		name string
+		v    **int
+	}{
+		{"us_aqi", &aq.AQIUS},
+		{"european_aqi", &aq.AQIEU},
+	} {
+		if *f.v != nil && **f.v < 0 {
+			clamped[f.name] = float64(**f.v)
+			*f.v = new(int)
+		}
+	}
+	if len(clamped) == 0 {
+		return aq
+	}
+
+	if opts.Verbose {
+		fmt.Fprintf(os.Stderr, "debug: clamped negative air values to 0: %v\n", clamped)
+	}
+	aq.Clamped = clamped
+	return aq
+}
+
+// ---------- Gas grading ----------
+// 국내 환경기준(ppm)을 25℃ 기준 ㎍/m³로 환산한 값
+func o3GradeKR(v float64) string  { return gradeNamesKR[gradeIndex(v, []float64{60, 180, 300})] }
+func no2GradeKR(v float64) string { return gradeNamesKR[gradeIndex(v, []float64{57, 113, 376})] }
+func so2GradeKR(v float64) string { return gradeNamesKR[gradeIndex(v, []float64{52, 131, 393})] }
+func coGradeKR(v float64) string  { return gradeNamesKR[gradeIndex(v, []float64{2290, 10300, 17200})] }
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+// 나쁜 등급이 먼저, 같은 등급은 원래 순서 (PM10, PM2.5, O₃, NO₂, SO₂, CO) 를 유지한다.
+func TestSortBySeverity(t *testing.T) {
+	cases := []struct {
+		name string
+		aq   AirQualityCurrent
+		want []string
+	}{
+		{
+			"pm only, all good", AirQualityCurrent{PM10: 20, PM25: 10},
+			[]string{"pm10", "pm25"},
+		},
+		{
+			"pm25 worse", AirQualityCurrent{PM10: 20, PM25: 40},
+			[]string{"pm25", "pm10"},
+		},
+		{
+			"gases", AirQualityCurrent{PM10: 20, PM25: 40, Ozone: ptr(200.0), NO2: ptr(400.0), CO: ptr(100.0)},
+			[]string{"no2", "pm25", "o3", "pm10", "co"},
+		},
+		{
+			"ties keep order", AirQualityCurrent{PM10: 100, PM25: 50, Ozone: ptr(200.0), SO2: ptr(10.0)},
+			[]string{"pm10", "pm25", "o3", "so2"},
+		},
+	}
+	for _, c := range cases {
+		ps := pollutants(c.aq)
+		sortBySeverity(ps)
+		if len(ps) != len(c.want) {
+			t.Errorf("%s: got %d pollutants, want %d", c.name, len(ps), len(c.want))
+			continue
+		}
+		for i, f := range c.want {
+			if ps[i].Field != f {
+				t.Errorf("%s: #%d = %s (%s), want %s", c.name, i, ps[i].Field, ps[i].Grade, f)
+			}
+		}
+	}
+}
+
+func TestGradeSeverityOrder(t *testing.T) {
+	order := []string{"좋음", "보통", "나쁨", "매우 나쁨"}
+	for i := 1; i < len(order); i++ {
+		if gradeSeverity(order[i-1]) >= gradeSeverity(order[i]) {
+			t.Errorf("%s should rank below %s", order[i-1], order[i])
+		}
+	}
+	if gradeSeverity("알 수 없음") >= gradeSeverity("좋음") {
+		t.Error("unknown grade should rank below 좋음")
+	}
+}
+
+func TestTopPollutantText(t *testing.T) {
+	cases := []struct {
+		aq   AirQualityCurrent
+		want string
+	}{
+		{AirQualityCurrent{PM10: 20, PM25: 10}, "주요 오염물질: 없음 (모두 좋음)"},
+		{AirQualityCurrent{PM10: 20, PM25: 40}, "주요 오염물질: 초미세먼지(PM2.5) (나쁨)"},
+		{AirQualityCurrent{PM10: 100, PM25: 50, Ozone: ptr(10.0)}, "주요 오염물질: 미세먼지(PM10), 초미세먼지(PM2.5) (나쁨)"},
+	}
+	for _, c := range cases {
+		if got := topPollutantText(c.aq); got != c.want {
+			t.Errorf("topPollutantText(%+v) = %q, want %q", c.aq, got, c.want)
+		}
+	}
+}
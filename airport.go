@@ -0,0 +1,95 @@
+package main
+
+import "strings"
+
+// ---------- Airports ----------
+// 사용자가 ICN, RKSI 처럼 대문자 3~4자 공항 코드를 주면 내장 표에서 찾는다.
+// 표에 없으면 평소처럼 도시 이름으로 지오코딩한다.
+type airport struct {
+	IATA, ICAO     string
+	NameKO, NameEN string
+	CountryCode    string
+	Lat, Lon       float64
+}
+
+var airports = []airport{
+	{"ICN", "RKSI", "인천국제공항", "Incheon International Airport", "KR", 37.4602, 126.4407},
+	{"GMP", "RKSS", "김포국제공항", "Gimpo International Airport", "KR", 37.5583, 126.7906},
+	{"PUS", "RKPK", "김해국제공항", "Gimhae International Airport", "KR", 35.1795, 128.9382},
+	{"CJU", "RKPC", "제주국제공항", "Jeju International Airport", "KR", 33.5113, 126.4930},
+	{"NRT", "RJAA", "나리타국제공항", "Narita International Airport", "JP", 35.7720, 140.3929},
+	{"HND", "RJTT", "하네다공항", "Haneda Airport", "JP", 35.5494, 139.7798},
+	{"KIX", "RJBB", "간사이국제공항", "Kansai International Airport", "JP", 34.4347, 135.2440},
+	{"PEK", "ZBAA", "베이징 서우두 국제공항", "Beijing Capital International Airport", "CN", 40.0799, 116.6031},
+	{"PVG", "ZSPD", "상하이 푸둥 국제공항", "Shanghai Pudong International Airport", "CN", 31.1443, 121.8083},
+	{"HKG", "VHHH", "홍콩국제공항", "Hong Kong International Airport", "HK", 22.3080, 113.9185},
+	{"TPE", "RCTP", "타오위안 국제공항", "Taoyuan International Airport", "TW", 25.0797, 121.2342},
+	{"SIN", "WSSS", "싱가포르 창이 공항", "Singapore Changi Airport", "SG", 1.3644, 103.9915},
+	{"BKK", "VTBS", "수완나품 공항", "Suvarnabhumi Airport", "TH", 13.6900, 100.7501},
+	{"DXB", "OMDB", "두바이 국제공항", "Dubai International Airport", "AE", 25.2532, 55.3657},
+	{"LHR", "EGLL", "런던 히스로 공항", "London Heathrow Airport", "GB", 51.4700, -0.4543},
+	{"CDG", "LFPG", "파리 샤를 드골 공항", "Paris Charles de Gaulle Airport", "FR", 49.0097, 2.5479},
+	{"FRA", "EDDF", "프랑크푸르트 공항", "Frankfurt Airport", "DE", 50.0379, 8.5622},
+	{"AMS", "EHAM", "암스테르담 스히폴 공항", "Amsterdam Airport Schiphol", "NL", 52.3105, 4.7683},
+	{"JFK", "KJFK", "존 F. 케네디 국제공항", "John F. Kennedy International Airport", "US", 40.6413, -73.7781},
+	{"LAX", "KLAX", "로스앤젤레스 국제공항", "Los Angeles International Airport", "US", 33.9416, -118.4085},
+	{"SFO", "KSFO", "샌프란시스코 국제공항", "San Francisco International Airport", "US", 37.6213, -122.3790},
+	{"ORD", "KORD", "시카고 오헤어 국제공항", "Chicago O'Hare International Airport", "US", 41.9742, -87.9073},
+	{"SEA", "KSEA", "시애틀 터코마 국제공항", "Seattle-Tacoma International Airport", "US", 47.4502, -122.3088},
+	{"YVR", "CYVR", "밴쿠버 국제공항", "Vancouver International Airport", "CA", 49.1967, -123.1815},
+	{"SYD", "YSSY", "시드니 공항", "Sydney Airport", "AU", -33.9399, 151.1753},
+}
+
+var countryNames = map[string][2]string{ // code → {ko, en}
+	"KR": {"대한민국", "South Korea"},
+	"JP": {"일본", "Japan"},
+	"CN": {"중국", "China"},
+	"HK": {"홍콩", "Hong Kong"},
+	"TW": {"대만", "Taiwan"},
+	"SG": {"싱가포르", "Singapore"},
+	"TH": {"태국", "Thailand"},
+	"AE": {"아랍에미리트", "United Arab Emirates"},
+	"GB": {"영국", "United Kingdom"},
+	"FR": {"프랑스", "France"},
+	"DE": {"독일", "Germany"},
+	"NL": {"네덜란드", "Netherlands"},
+	"US": {"미국", "United States"},
+	"CA": {"캐나다", "Canada"},
+	"AU": {"호주", "Australia"},
+}
+
+// looksLikeAirportCode는 공백 없는 대문자 3자(IATA) 또는 4자(ICAO)인지 본다.
+// 소문자는 도시 이름으로 본다. ("rome", "oslo")
+func looksLikeAirportCode(s string) bool {
+	if len(s) != 3 && len(s) != 4 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupAirport는 IATA/ICAO 코드로 공항을 찾는다. 이름과 국가는 lang에 맞춘다.
+func lookupAirport(code, lang string) (GeoResult, bool) {
+	code = strings.TrimSpace(code)
+	for _, a := range airports {
+		if code != a.IATA && code != a.ICAO {
+			continue
+		}
+		name, country := a.NameKO, countryNames[a.CountryCode][0]
+		if lang == "en" {
+			name, country = a.NameEN, countryNames[a.CountryCode][1]
+		}
+		return GeoResult{
+			Name:        name + " (" + a.IATA + ")",
+			Country:     country,
+			CountryCode: a.CountryCode,
+			Latitude:    a.Lat,
+			Longitude:   a.Lon,
+		}, true
+	}
+	return GeoResult{}, false
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLookupAirport(t *testing.T) {
+	cases := []struct {
+		code, lang string
+		name       string
+		country    string
+		lat, lon   float64
+	}{
+		{"ICN", "ko", "인천국제공항 (ICN)", "대한민국", 37.4602, 126.4407},
+		{"RKSI", "ko", "인천국제공항 (ICN)", "대한민국", 37.4602, 126.4407},
+		{"ICN", "en", "Incheon International Airport (ICN)", "South Korea", 37.4602, 126.4407},
+		{"JFK", "ko", "존 F. 케네디 국제공항 (JFK)", "미국", 40.6413, -73.7781},
+		{"KJFK", "en", "John F. Kennedy International Airport (JFK)", "United States", 40.6413, -73.7781},
+		{" JFK ", "en", "John F. Kennedy International Airport (JFK)", "United States", 40.6413, -73.7781},
+	}
+	for _, c := range cases {
+		got, ok := lookupAirport(c.code, c.lang)
+		if !ok {
+			t.Errorf("lookupAirport(%q) not found", c.code)
+			continue
+		}
+		if got.Name != c.name || got.Country != c.country || got.Latitude != c.lat || got.Longitude != c.lon {
+			t.Errorf("lookupAirport(%q, %s) = %+v", c.code, c.lang, got)
+		}
+	}
+	for _, code := range []string{"ABC", "ZZZZ", "icn", ""} {
+		if _, ok := lookupAirport(code, "ko"); ok {
+			t.Errorf("lookupAirport(%q) found, want not found", code)
+		}
+	}
+}
+
+func TestLooksLikeAirportCode(t *testing.T) {
+	cases := map[string]bool{
+		"ICN": true, "RKSI": true, "ROMA": true,
+		"icn": false, "Rome": false, "NY": false, "LONDON": false, "IC1": false, "서울": false, "": false,
+	}
+	for s, want := range cases {
+		if got := looksLikeAirportCode(s); got != want {
+			t.Errorf("looksLikeAirportCode(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+// 표에 있는 코드는 지오코딩하지 않고, 코드처럼 보여도 표에 없으면 도시 이름으로 지오코딩한다.
+func TestLookupCityAirportFallback(t *testing.T) {
+	fake := &fakeGeocoder{places: map[string]GeoResult{
+		"ROMA": {ID: 1, Name: "Roma", Country: "Italia", Latitude: 41.8919, Longitude: 12.5113},
+	}}
+	opts := testOptions()
+	opts.Geocoder = fake
+
+	cases := []struct {
+		city, want string
+		geocoded   bool
+	}{
+		{"ICN", "인천국제공항 (ICN)", false},
+		{"KJFK", "존 F. 케네디 국제공항 (JFK)", false},
+		{"ROMA", "Roma", true},
+	}
+	for _, c := range cases {
+		before := len(fake.calls)
+		loc, err := lookupCity(context.Background(), http.DefaultClient, c.city, opts)
+		if err != nil {
+			t.Fatalf("%s: %v", c.city, err)
+		}
+		if loc.Name != c.want {
+			t.Errorf("%s: name = %q, want %q", c.city, loc.Name, c.want)
+		}
+		if geocoded := len(fake.calls) > before; geocoded != c.geocoded {
+			t.Errorf("%s: geocoded = %v, want %v", c.city, geocoded, c.geocoded)
+		}
+	}
+}
+
+// 내장 표의 공항은 모두 좌표와 국가 이름이 있고 코드가 겹치지 않는다.
+func TestAirportTable(t *testing.T) {
+	seen := map[string]bool{}
+	for _, a := range airports {
+		for _, code := range []string{a.IATA, a.ICAO} {
+			if seen[code] || !looksLikeAirportCode(code) {
+				t.Errorf("%s: duplicate or malformed code", code)
+			}
+			seen[code] = true
+		}
+		if _, ok := countryNames[a.CountryCode]; !ok {
+			t.Errorf("%s: no country name for %s", a.IATA, a.CountryCode)
+		}
+		if loc, _ := lookupAirport(a.IATA, "ko"); !validGeoResult(loc) {
+			t.Errorf("%s: invalid location %+v", a.IATA, loc)
+		}
+	}
+}
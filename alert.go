@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ---------- Temperature alerts ----------
+// --min-temp-alert / --max-temp-alert 는 현재 기온이나 오늘 예보 최저/최고가
+// 기준을 넘으면 경고 줄을 출력하고 exit 3 으로 끝낸다. (스크립트용)
+// --if "temp>30" 처럼 조건을 직접 주면, 맞을 때 같은 방식으로 경고한다.
+// 기준값은 --unit 과 같은 단위로 받고 (--unit=f 면 °F, auto 면 위치에 따라 정해진 단위),
+// 비교는 기온과 기준을 모두 °C로 바꿔서 한다.
+
+// errTempAlert는 경고가 났다는 표시다. main에서 exit 3 으로 바꾼다.
+var errTempAlert = errors.New("temperature alert")
+
+const tempAlertExitCode = 3
+
+// optFloat는 "주지 않음"과 0을 구분하는 float flag다.
+type optFloat struct {
+	v   float64
+	set bool
+}
+
+func (f *optFloat) String() string {
+	if f == nil || !f.set {
+		return ""
+	}
+	return strconv.FormatFloat(f.v, 'f', -1, 64)
+}
+
+func (f *optFloat) Set(s string) error {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %q", s)
+	}
+	f.v, f.set = v, true
+	return nil
+}
+
+// tempCond는 --if 조건 "<temp|feels><op><값>" 이다. op는 >, >=, <, <= 이다.
+type tempCond struct {
+	Field string
+	Op    string
+	V     float64
+	set   bool
+}
+
+func (c *tempCond) String() string {
+	if c == nil || !c.set {
+		return ""
+	}
+	return c.Field + c.Op + strconv.FormatFloat(c.V, 'f', -1, 64)
+}
+
+func (c *tempCond) Set(s string) error {
+	expr := strings.ReplaceAll(s, " ", "")
+	i := strings.IndexAny(expr, "<>")
+	if i < 0 {
+		return fmt.Errorf("invalid condition %q (예: temp>30, feels<=0)", s)
+	}
+	field, op, num := expr[:i], expr[i:i+1], expr[i+1:]
+	if strings.HasPrefix(num, "=") {
+		op, num = op+"=", num[1:]
+	}
+	if field != "temp" && field != "feels" {
+		return fmt.Errorf("invalid condition %q (temp, feels)", s)
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return fmt.Errorf("invalid condition %q: invalid number %q", s, num)
+	}
+	*c = tempCond{Field: field, Op: op, V: v, set: true}
+	return nil
+}
+
+// match는 w가 조건에 맞는지 본다. 값은 --unit 단위이고, 비교는 °C로 바꿔서 한다.
+func (c tempCond) match(w Current, opts Options) (value float64, ok bool) {
+	value = w.Temperature2m
+	if c.Field == "feels" {
+		value = w.ApparentTemperature
+	}
+	t, limit := opts.celsius(value), opts.celsius(c.V)
+	switch c.Op {
+	case ">":
+		ok = t > limit && !t.Equal(limit, tempEpsilon)
+	case ">=":
+		ok = t.AtLeast(limit)
+	case "<":
+		ok = t < limit && !t.Equal(limit, tempEpsilon)
+	case "<=":
+		ok = t.AtMost(limit)
+	}
+	return value, ok
+}
+
+// tempAlert는 기준을 넘은 항목 하나다. Kind는 "frost", "heat", 또는 (--if) "if"다.
+type tempAlert struct {
+	Kind      string  `json:"kind"`
+	Value     float64 `json:"value"`               // 기준을 넘은 기온 (--unit 단위)
+	Condition string  `json:"condition,omitempty"` // --if 조건
+}
+
+// tempAlerts는 기준을 넘은 항목을 돌려준다.
+// 최저는 현재 기온과 오늘 최저 중 낮은 쪽, 최고는 높은 쪽으로 본다.
+func tempAlerts(w Current, opts Options) []tempAlert {
+	var alerts []tempAlert
+	if opts.MinTempAlert.set {
+		low := w.Temperature2m
+		if w.TodayMin != nil && *w.TodayMin < low {
+			low = *w.TodayMin
+		}
+		if opts.celsius(low).AtMost(opts.celsius(opts.MinTempAlert.v)) {
+			alerts = append(alerts, tempAlert{Kind: "frost", Value: low})
+		}
+	}
+	if opts.MaxTempAlert.set {
+		high := w.Temperature2m
+		if w.TodayMax != nil && *w.TodayMax > high {
+			high = *w.TodayMax
+		}
+		if opts.celsius(high).AtLeast(opts.celsius(opts.MaxTempAlert.v)) {
+			alerts = append(alerts, tempAlert{Kind: "heat", Value: high})
+		}
+	}
+	if opts.IfCond.set {
+		if v, ok := opts.IfCond.match(w, opts); ok {
+			alerts = append(alerts, tempAlert{Kind: "if", Value: v, Condition: opts.IfCond.String()})
+		}
+	}
+	return alerts
+}
+
+// tempAlertText는 "서리 주의 (최저 -2.0°C)" 형태다.
+func tempAlertText(a tempAlert, opts Options) string {
+	v := opts.Precision.format("temp", a.Value) + tempSymbol(opts)
+	switch a.Kind {
+	case "frost":
+		return "서리 주의 (최저 " + v + ")"
+	case "if":
+		return "조건 충족 (" + a.Condition + ", 현재 " + v + ")"
+	}
+	return "폭염 주의 (최고 " + v + ")"
+}
+
+func alertPrefix(opts Options) string {
+	if opts.ascii() {
+		return "[!]"
+	}
+	return "⚠️"
+}
+
+func printTempAlerts(w Current, opts Options) {
+	for _, a := range tempAlerts(w, opts) {
+		fmt.Println(alertPrefix(opts) + " " + tempAlertText(a, opts))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+// 같은 기준을 °C와 °F로 주면 (30°C = 86°F, 0°C = 32°F) 결과가 같아야 한다. 경계값도 포함한다.
+func TestTempAlertsAcrossUnits(t *testing.T) {
+	tests := []struct {
+		name  string
+		tempC float64
+		set   func(o *Options, f func(c float64) float64)
+		want  string // 빈 값이면 경고 없음
+	}{
+		{"max at limit", 30, func(o *Options, f func(float64) float64) { o.MaxTempAlert = optFloat{f(30), true} }, "heat"},
+		{"max below", 29.9, func(o *Options, f func(float64) float64) { o.MaxTempAlert = optFloat{f(30), true} }, ""},
+		{"min at limit", 0, func(o *Options, f func(float64) float64) { o.MinTempAlert = optFloat{f(0), true} }, "frost"},
+		{"min above", 0.5, func(o *Options, f func(float64) float64) { o.MinTempAlert = optFloat{f(0), true} }, ""},
+		{"if > at limit", 30, func(o *Options, f func(float64) float64) { o.IfCond = tempCond{"temp", ">", f(30), true} }, ""},
+		{"if > above", 30.5, func(o *Options, f func(float64) float64) { o.IfCond = tempCond{"temp", ">", f(30), true} }, "if"},
+		{"if >= at limit", 30, func(o *Options, f func(float64) float64) { o.IfCond = tempCond{"temp", ">=", f(30), true} }, "if"},
+		{"if < below", -5, func(o *Options, f func(float64) float64) { o.IfCond = tempCond{"feels", "<", f(0), true} }, "if"},
+		{"if <= above", 1, func(o *Options, f func(float64) float64) { o.IfCond = tempCond{"feels", "<=", f(0), true} }, ""},
+	}
+	units := []struct {
+		unit string
+		conv func(float64) float64
+	}{
+		{"c", func(c float64) float64 { return c }},
+		{"f", func(c float64) float64 { return c*9/5 + 32 }},
+	}
+	for _, tt := range tests {
+		for _, u := range units {
+			opts := testOptions()
+			opts.Unit = u.unit
+			tt.set(&opts, u.conv)
+			w := Current{Temperature2m: u.conv(tt.tempC), ApparentTemperature: u.conv(tt.tempC)}
+
+			got := ""
+			if alerts := tempAlerts(w, opts); len(alerts) > 0 {
+				got = alerts[0].Kind
+			}
+			if got != tt.want {
+				t.Errorf("%s (unit=%s): alert %q, want %q", tt.name, u.unit, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestTempCondSet(t *testing.T) {
+	for in, want := range map[string]string{
+		"temp>30":    "temp>30",
+		"feels <= 0": "feels<=0",
+		"temp>=-2.5": "temp>=-2.5",
+		"temp<86":    "temp<86",
+	} {
+		var c tempCond
+		if err := c.Set(in); err != nil || c.String() != want {
+			t.Errorf("Set(%q) = %q, %v; want %q", in, c.String(), err, want)
+		}
+	}
+	for _, in := range []string{"", "temp", "humidity>50", "temp>>3", "temp=30", "temp>abc"} {
+		var c tempCond
+		if err := c.Set(in); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", in)
+		}
+	}
+}
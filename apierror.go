@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ---------- API errors ----------
+// APIError는 Open-Meteo 호출 실패다. errors.As 로 꺼내 엔드포인트/상태 코드별로 처리한다.
+type APIError struct {
+	Endpoint   string // "geocoding", "weather", "air quality", ...
+	StatusCode int    // HTTP 상태 코드 (응답을 못 받았으면 0)
+	Reason     string // "request failed", "bad status", "decode failed"
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	msg := e.Endpoint + " " + e.Reason
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(": %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+		if e.Err != nil {
+			msg += " (" + e.Err.Error() + ")"
+		}
+		return msg
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *APIError) Unwrap() error { return e.Err }
+
+func requestError(endpoint string, err error) *APIError {
+	return &APIError{Endpoint: endpoint, Reason: "request failed", Err: err}
+}
+
+func decodeError(endpoint string, err error) *APIError {
+	return &APIError{Endpoint: endpoint, Reason: "decode failed", Err: err}
+}
+
+// statusError는 200이 아닌 응답이다.
+// Open-Meteo는 {"error": true, "reason": "..."} 를 주므로 있으면 Err에 담는다.
+func statusError(endpoint string, resp *http.Response) *APIError {
+	e := &APIError{Endpoint: endpoint, StatusCode: resp.StatusCode, Reason: "bad status"}
+
+	var envelope struct {
+		Reason string `json:"reason"`
+	}
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	if json.Unmarshal(b, &envelope) == nil && envelope.Reason != "" {
+		e.Err = apiReason(envelope.Reason)
+	}
+	return e
+}
+
+// apiReason은 Open-Meteo 에러 응답의 reason 문구다.
+type apiReason string
+
+func (r apiReason) Error() string { return string(r) }
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 세 fetcher 모두 *APIError 를 돌려주고, 감싸져 있어도 errors.As 로 필드를 꺼낼 수 있다.
+func TestAPIErrorAs(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		fetch    func(ctx context.Context, client *http.Client) error
+		endpoint string
+		reason   string
+		apiMsg   string // Err 에 담긴 Open-Meteo reason
+	}{
+		{
+			"geocode 400", http.StatusBadRequest, `{"error":true,"reason":"Parameter count must be between 1 and 100."}`,
+			func(ctx context.Context, c *http.Client) error {
+				_, err := searchPlaces(ctx, c, "seoul", "ko", 1)
+				return err
+			},
+			"geocoding", "bad status", "Parameter count must be between 1 and 100.",
+		},
+		{
+			"forecast 502", http.StatusBadGateway, `<html>bad gateway</html>`,
+			func(ctx context.Context, c *http.Client) error {
+				_, err := fetchCurrentWeather(ctx, c, 37.566, 126.9784, queryOpts{})
+				return err
+			},
+			"weather", "bad status", "",
+		},
+		{
+			"air decode", http.StatusOK, `{"current":`,
+			func(ctx context.Context, c *http.Client) error {
+				_, err := fetchAirQuality(ctx, c, 37.566, 126.9784, queryOpts{})
+				return err
+			},
+			"air quality", "decode failed", "",
+		},
+	}
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			io.WriteString(w, c.body)
+		}))
+		orig := endpoints
+		endpoints.Geocode, endpoints.Forecast, endpoints.Air = []string{srv.URL}, []string{srv.URL}, []string{srv.URL}
+
+		err := fmt.Errorf("run failed: %w", c.fetch(context.Background(), srv.Client()))
+		endpoints = orig
+		srv.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: err = %v, want *APIError", c.name, err)
+		}
+		if apiErr.Endpoint != c.endpoint || apiErr.Reason != c.reason {
+			t.Errorf("%s: got %s/%s, want %s/%s", c.name, apiErr.Endpoint, apiErr.Reason, c.endpoint, c.reason)
+		}
+		want := c.status
+		if c.reason != "bad status" {
+			want = 0 // 200 응답의 decode 실패는 상태 코드를 남기지 않는다.
+		}
+		if apiErr.StatusCode != want {
+			t.Errorf("%s: status = %d, want %d", c.name, apiErr.StatusCode, want)
+		}
+		var reason apiReason
+		if got := errors.As(err, &reason); got != (c.apiMsg != "") || string(reason) != c.apiMsg {
+			t.Errorf("%s: api reason = %q, want %q", c.name, reason, c.apiMsg)
+		}
+	}
+}
+
+// 연결 실패는 상태 코드 없이 "request failed" 이고 원래 에러가 체인에 남는다.
+func TestAPIErrorRequestFailed(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close() // 닫힌 서버로 연결 실패를 만든다.
+
+	orig := endpoints
+	endpoints.Air = []string{url}
+	defer func() { endpoints = orig }()
+
+	_, err := fetchAirQuality(context.Background(), http.DefaultClient, 37.566, 126.9784, queryOpts{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *APIError", err)
+	}
+	if apiErr.Endpoint != "air quality" || apiErr.Reason != "request failed" || apiErr.StatusCode != 0 || apiErr.Err == nil {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if errors.Unwrap(apiErr) != apiErr.Err {
+		t.Error("Unwrap does not return Err")
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+// EPA PM2.5 구간의 양 끝에서 AQI가 표의 값과 맞는지 본다.
+func TestAQIFromPM25(t *testing.T) {
+	cases := []struct {
+		pm   float64
+		want int
+	}{
+		{-3, 0},
+		{0, 0},
+		{9.0, 38},
+		{12.0, 50},
+		{12.05, 50}, // 소수 첫째 자리에서 절사해 12.0으로 본다.
+		{12.1, 51},
+		{22, 72},
+		{35.4, 100},
+		{35.5, 101},
+		{55.4, 150},
+		{55.5, 151},
+		{150.4, 200},
+		{150.5, 201},
+		{250.4, 300},
+		{250.5, 301},
+		{350.4, 400},
+		{350.5, 401},
+		{500.4, 500},
+		{720, 500},
+	}
+	for _, c := range cases {
+		if got := aqiFromPM25(c.pm); got != c.want {
+			t.Errorf("aqiFromPM25(%v) = %d, want %d", c.pm, got, c.want)
+		}
+	}
+}
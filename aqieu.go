@@ -0,0 +1,43 @@
+package main
+
+import "fmt"
+
+// ---------- European AQI ----------
+// --aqi-standard 로 대기질 첫 줄의 지수를 고른다. 기본은 US AQI다.
+const (
+	aqiStandardUS = "us"
+	aqiStandardEU = "eu"
+)
+
+// EEA European AQI 구간 (각 값은 상한, 이하)
+var (
+	euAQICutoffs = []float64{20, 40, 60, 80, 100}
+	aqiNamesEU   = []string{"Good", "Fair", "Moderate", "Poor", "Very Poor", "Extremely Poor"}
+	aqiNamesEUKR = []string{"좋음", "양호", "보통", "나쁨", "매우 나쁨", "극히 나쁨"}
+	aqiEmojiEU   = []string{"😊", "🙂", "😐", "😷", "🤢", "☠️"}
+)
+
+// aqiStatusEU는 EEA 등급 이름이다. (Good/Fair/Moderate/Poor/Very Poor/Extremely Poor)
+func aqiStatusEU(aqi int) string {
+	return aqiNamesEU[gradeIndex(float64(aqi), euAQICutoffs)]
+}
+
+func aqiLabelEU(aqi int) string {
+	return aqiNamesEUKR[gradeIndex(float64(aqi), euAQICutoffs)]
+}
+
+// euAirStatusLine은 "대기질 보통 😐 (EAQI 45)" 줄이다.
+func euAirStatusLine(aqi int, opts Options) string {
+	i := gradeIndex(float64(aqi), euAQICutoffs)
+	name := aqiNamesEUKR[i]
+	if !opts.ascii() {
+		name += " " + aqiEmojiEU[i]
+	}
+	explain := ""
+	if opts.Explain {
+		_, lo, hi := gradeRange(float64(aqi), euAQICutoffs)
+		explain = " (" + explainRange(lo, hi, float64(aqi), "") + ")"
+	}
+	status := opts.colorGrade(aqiNamesEUKR[i], opts.dual(name, aqiNamesEU[i]))
+	return fmt.Sprintf("%s %s (EAQI %d)%s", opts.label("air"), status, aqi, explain)
+}
@@ -0,0 +1,43 @@
+package main
+
+import "testing"
+
+// EEA 구간은 상한을 포함한다. (20은 Good, 21은 Fair)
+func TestAQIStatusEU(t *testing.T) {
+	cases := []struct {
+		aqi    int
+		en, ko string
+	}{
+		{0, "Good", "좋음"},
+		{20, "Good", "좋음"},
+		{21, "Fair", "양호"},
+		{40, "Fair", "양호"},
+		{41, "Moderate", "보통"},
+		{60, "Moderate", "보통"},
+		{61, "Poor", "나쁨"},
+		{80, "Poor", "나쁨"},
+		{81, "Very Poor", "매우 나쁨"},
+		{100, "Very Poor", "매우 나쁨"},
+		{101, "Extremely Poor", "극히 나쁨"},
+		{250, "Extremely Poor", "극히 나쁨"},
+	}
+	for _, c := range cases {
+		if got := aqiStatusEU(c.aqi); got != c.en {
+			t.Errorf("aqiStatusEU(%d) = %s, want %s", c.aqi, got, c.en)
+		}
+		if got := aqiLabelEU(c.aqi); got != c.ko {
+			t.Errorf("aqiLabelEU(%d) = %s, want %s", c.aqi, got, c.ko)
+		}
+	}
+}
+
+func TestEUAirStatusLine(t *testing.T) {
+	opts := testOptions()
+	if got, want := euAirStatusLine(45, opts), "대기질 보통 😐 (EAQI 45)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	opts.IconSet = iconSetASCII
+	if got, want := euAirStatusLine(101, opts), "대기질 극히 나쁨 (EAQI 101)"; got != want {
+		t.Errorf("ascii: got %q, want %q", got, want)
+	}
+}
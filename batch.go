@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ---------- Batch (--from) ----------
+// 파일(또는 - 이면 stdin)의 도시를 한 줄에 하나씩 차례로 조회한다.
+// 기본은 한 도시가 실패해도 (지오코딩 포함) 나머지를 계속 조회하고,
+// 하나라도 실패하면 마지막에 에러를 돌려준다. --fail-fast-geocode 면 첫 실패에서 멈춘다.
+// Ctrl-C나 --deadline 으로 중간에 멈추면 남은 도시를 batchError.Skipped 에 남기고 에러로 끝낸다.
+
+// cityError는 배치에서 한 도시의 실패를 기록한다.
+type cityError struct {
+	City string
+	Err  error
+}
+
+func (e *cityError) Error() string { return fmt.Sprintf("%s: %v", e.City, e.Err) }
+func (e *cityError) Unwrap() error { return e.Err }
+
+// batchError는 실패한 도시들을 모은다.
+// Skipped는 중단되어 조회하지 못한 도시이고, Cause는 중단 이유 (ctx.Err())다.
+type batchError struct {
+	Failed  []*cityError
+	Total   int
+	Skipped []string
+	Cause   error
+}
+
+func (e *batchError) Error() string {
+	msg := fmt.Sprintf("%d of %d cities failed", len(e.Failed), e.Total)
+	if len(e.Skipped) > 0 {
+		msg += fmt.Sprintf(", %d not processed (interrupted: %v)", len(e.Skipped), e.Cause)
+	}
+	return msg
+}
+
+func (e *batchError) Unwrap() error { return e.Cause }
+
+// interrupted는 ctx가 끝나 멈춘 배치다. rest는 아직 결과를 내지 못한 도시들이다.
+func (e *batchError) interrupted(ctx context.Context, rest []string) error {
+	e.Skipped, e.Cause = rest, ctx.Err()
+	return e
+}
+
+func RunBatch(ctx context.Context, cities []string, opts Options) error {
+	if opts.Sort != "" {
+		return runBatchSorted(ctx, cities, opts)
+	}
+
+	be := &batchError{Total: len(cities)}
+	alerted := false
+
+	for i, city := range cities {
+		if i > 0 && !opts.JSON {
+			fmt.Println("")
+		}
+		if err := RunNow(ctx, city, opts); err != nil {
+			if errors.Is(err, errTempAlert) {
+				alerted = true
+				continue
+			}
+			if ctx.Err() != nil {
+				return be.interrupted(ctx, cities[i:])
+			}
+			ce := &cityError{City: city, Err: err}
+			if opts.FailFastGeocode {
+				return ce
+			}
+			fmt.Fprintf(os.Stderr, "error: %v\n", ce)
+			be.Failed = append(be.Failed, ce)
+		}
+	}
+	return be.or(alerted)
+}
+
+// or는 실패하거나 건너뛴 도시가 있으면 be, 없고 기온 경고만 있었으면 errTempAlert 다.
+func (be *batchError) or(alerted bool) error {
+	if len(be.Failed) > 0 || len(be.Skipped) > 0 {
+		return be
+	}
+	if alerted {
+		return errTempAlert
+	}
+	return nil
+}
+
+// batchItem은 도시별로 확정된 opts(--unit=auto 등)를 Report와 함께 둔다.
+type batchItem struct {
+	report Report
+	opts   Options
+}
+
+// runBatchSorted는 모두 조회한 뒤 opts.Sort 순서로 출력한다.
+// condition: 맑음 → 뇌우 순 (codeSeverity), 날씨 정보가 없는 도시는 맨 뒤
+// 중간에 멈추면 그때까지 받은 도시만 정렬해 출력하고 나머지는 Skipped로 남긴다.
+func runBatchSorted(ctx context.Context, cities []string, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	be := &batchError{Total: len(cities)}
+	var items []batchItem
+	for i, city := range cities {
+		dctx, cancel := opts.withDeadline(ctx)
+		r, o, err := fetchReport(dctx, client, city, opts)
+		err = opts.deadlineErr(dctx, err)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				be.Skipped, be.Cause = cities[i:], ctx.Err()
+				break
+			}
+			ce := &cityError{City: city, Err: err}
+			if opts.FailFastGeocode {
+				return ce
+			}
+			fmt.Fprintf(os.Stderr, "error: %v\n", ce)
+			be.Failed = append(be.Failed, ce)
+			continue
+		}
+		if !opts.DryRun {
+			items = append(items, batchItem{report: r, opts: o})
+		}
+	}
+
+	alerted := false
+	sort.SliceStable(items, func(i, j int) bool {
+		return conditionRank(items[i].report) < conditionRank(items[j].report)
+	})
+	for i, it := range items {
+		if i > 0 && !opts.JSON {
+			fmt.Println("")
+		}
+		if err := emitReport(ctx, client, it.report, it.opts); err != nil {
+			if !errors.Is(err, errTempAlert) {
+				return err
+			}
+			alerted = true
+		}
+	}
+	return be.or(alerted)
+}
+
+func conditionRank(r Report) int {
+	if r.WeatherErr != nil {
+		return 1 << 10
+	}
+	if sev := codeSeverity(r.Weather.WeatherCode); sev >= 0 {
+		return sev
+	}
+	return 1 << 9 // 모르는 코드
+}
+
+// readCities는 빈 줄과 # 주석을 건너뛴다.
+func readCities(r io.Reader) ([]string, error) {
+	var cities []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cities = append(cities, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("city list read failed: %w", err)
+	}
+	return cities, nil
+}
+
+func loadCityList(path string) ([]string, error) {
+	if path == "-" {
+		return readCities(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("city list open failed: %w", err)
+	}
+	defer f.Close()
+	return readCities(f)
+}
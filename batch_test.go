@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var batchPlaces = map[string]GeoResult{
+	"seoul": {ID: 1, Name: "서울", Country: "대한민국", CountryCode: "KR", Latitude: 37.566, Longitude: 126.9784},
+	"busan": {ID: 2, Name: "부산", Country: "대한민국", CountryCode: "KR", Latitude: 35.1796, Longitude: 129.0756},
+}
+
+func TestRunBatchMixed(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+
+	for _, sortBy := range []string{"", "condition"} {
+		opts := testOptions()
+		opts.Sort = sortBy
+
+		var err error
+		out := captureStdout(t, func() { err = RunBatch(context.Background(), []string{"seoul", "nowhere", "busan"}, opts) })
+
+		var be *batchError
+		if !errors.As(err, &be) {
+			t.Fatalf("sort=%q: err = %v, want *batchError", sortBy, err)
+		}
+		if be.Total != 3 || len(be.Failed) != 1 || be.Failed[0].City != "nowhere" {
+			t.Errorf("sort=%q: batchError = %+v, want 1 of 3 failed (nowhere)", sortBy, be)
+		}
+		if !strings.Contains(out, "서울") || !strings.Contains(out, "부산") {
+			t.Errorf("sort=%q: successful cities missing from output:\n%s", sortBy, out)
+		}
+	}
+}
+
+func TestRunBatchAllSucceed(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+
+	var err error
+	captureStdout(t, func() { err = RunBatch(context.Background(), []string{"seoul", "busan"}, testOptions()) })
+	if err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+}
+
+func TestRunBatchFailFast(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+	opts := testOptions()
+	opts.FailFastGeocode = true
+
+	var err error
+	out := captureStdout(t, func() { err = RunBatch(context.Background(), []string{"nowhere", "seoul"}, opts) })
+
+	var ce *cityError
+	if !errors.As(err, &ce) || ce.City != "nowhere" {
+		t.Fatalf("err = %v, want *cityError for nowhere", err)
+	}
+	if strings.Contains(out, "서울") {
+		t.Errorf("fail-fast batch kept going:\n%s", out)
+	}
+}
+
+// 중단된 배치는 성공으로 끝나면 안 되고, 남은 도시를 알려야 한다.
+func TestRunBatchInterrupted(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, sortBy := range []string{"", "condition"} {
+		opts := testOptions()
+		opts.Sort = sortBy
+
+		var err error
+		captureStdout(t, func() { err = RunBatch(ctx, []string{"seoul", "busan"}, opts) })
+
+		var be *batchError
+		if !errors.As(err, &be) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("sort=%q: err = %v, want interrupted *batchError", sortBy, err)
+		}
+		if len(be.Skipped) != 2 {
+			t.Errorf("sort=%q: Skipped = %v, want both cities", sortBy, be.Skipped)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ---------- Disk cache ----------
+// 항목 하나가 파일 하나다: <dir>/<namespace>/<sha1(key)>.json
+// 읽기 실패나 깨진 파일은 캐시 미스로 취급한다.
+type diskCache struct {
+	dir string
+}
+
+type cacheEntry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Key     string          `json:"key"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// 지오코딩 결과는 거의 바뀌지 않는다.
+const geocodeCacheTTL = 30 * 24 * time.Hour
+
+// cacheDir는 --cache-dir > $WEATHER_CACHE_DIR > <UserCacheDir>/weather-cli 순으로 고른다.
+func cacheDir(flagDir string) (string, error) {
+	if flagDir != "" {
+		return flagDir, nil
+	}
+	if env := os.Getenv("WEATHER_CACHE_DIR"); env != "" {
+		return env, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cache dir: %w", err)
+	}
+	return filepath.Join(dir, "weather-cli"), nil
+}
+
+func (c diskCache) path(ns, key string) string {
+	sum := sha1.Sum([]byte(key))
+	return filepath.Join(c.dir, ns, hex.EncodeToString(sum[:])+".json")
+}
+
+// get은 ttl 안에 저장된 항목이 있으면 v에 채우고 true를 돌려준다.
+func (c diskCache) get(ns, key string, ttl time.Duration, v any) bool {
+	if c.dir == "" {
+		return false
+	}
+
+	b, err := os.ReadFile(c.path(ns, key))
+	if err != nil {
+		return false
+	}
+
+	var e cacheEntry
+	if err := json.Unmarshal(b, &e); err != nil || e.Key != key {
+		return false
+	}
+	if ttl > 0 && nowFunc().Sub(e.SavedAt) > ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+func (c diskCache) put(ns, key string, v any) error {
+	if c.dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("cache encode failed: %w", err)
+	}
+	b, err := json.Marshal(cacheEntry{SavedAt: nowFunc(), Key: key, Data: data})
+	if err != nil {
+		return fmt.Errorf("cache encode failed: %w", err)
+	}
+
+	p := c.path(ns, key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return cacheFSError("create", filepath.Dir(p), err)
+	}
+	if err := os.WriteFile(p, b, 0o644); err != nil {
+		return cacheFSError("write", p, err)
+	}
+	return nil
+}
+
+// files는 캐시가 만든 파일(<dir>/<ns>/*.json)만 돌려준다.
+// --cache-dir 로 엉뚱한 폴더를 지정해도 다른 파일은 건드리지 않는다.
+func (c diskCache) files() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*", "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("cache read %s: %w", c.dir, err)
+	}
+	return files, nil
+}
+
+// stats는 캐시 항목 수와 전체 크기를 센다. 디렉터리가 없으면 0이다.
+func (c diskCache) stats() (entries int, size int64, err error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return 0, 0, cacheFSError("read", f, err)
+		}
+		entries++
+		size += info.Size()
+	}
+	return entries, size, nil
+}
+
+// clear는 캐시 파일을 모두 지우고 지운 항목 수와 크기를 돌려준다.
+func (c diskCache) clear() (entries int, size int64, err error) {
+	files, err := c.files()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return entries, size, cacheFSError("read", f, err)
+		}
+		if err := os.Remove(f); err != nil {
+			return entries, size, cacheFSError("remove", f, err)
+		}
+		entries++
+		size += info.Size()
+	}
+
+	// 비어 있는 namespace 폴더만 정리한다.
+	dirs, _ := filepath.Glob(filepath.Join(c.dir, "*"))
+	for _, d := range dirs {
+		if info, err := os.Stat(d); err == nil && info.IsDir() {
+			_ = os.Remove(d) // 비어 있지 않으면 실패하고 그대로 둔다.
+		}
+	}
+	return entries, size, nil
+}
+
+func cacheFSError(op, path string, err error) error {
+	if errors.Is(err, fs.ErrPermission) {
+		return fmt.Errorf("cache %s %s: permission denied (--cache-dir 로 다른 위치를 지정해 보세요)", op, path)
+	}
+	return fmt.Errorf("cache %s %s: %w", op, path, err)
+}
+
+func humanBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
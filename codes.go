@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ---------- codes 명령 (숨김) ----------
+// printCodes는 WMO 코드마다 런타임이 쓰는 라벨/아이콘/심각도/slug를 표로 출력한다.
+// 라벨이 "알 수 없음"인 코드는 아이콘 매핑이 빠진 것이다.
+func printCodes() {
+	codes := make([]int, 0, len(wmoCodes))
+	for c := range wmoCodes {
+		codes = append(codes, c)
+	}
+	slices.Sort(codes)
+
+	fmt.Printf("%s %s %s %s %s\n", padRight("code", 5), padRight("emoji", 18), padRight("ascii", 18), padRight("severity", 9), "slug")
+	missing := 0
+	for _, c := range codes {
+		emoji := iconForCode(c, false)
+		if strings.HasSuffix(emoji, "알 수 없음") {
+			missing++
+		}
+		fmt.Printf("%s %s %s %s %s\n",
+			padRight(fmt.Sprint(c), 5),
+			padRight(emoji, 18),
+			padRight(iconForCodeASCII(c, false), 18),
+			padRight(fmt.Sprint(codeSeverity(c)), 9),
+			conditionSlug(c),
+		)
+	}
+	fmt.Printf("\n%d개 코드, 아이콘 매핑 없음 %d개\n", len(codes), missing)
+}
+
+// conditionSlug는 conditionEN을 "unknown-conditions" 처럼 소문자-대시로 바꾼다.
+func conditionSlug(code int) string {
+	return strings.ReplaceAll(strings.ToLower(conditionEN(code)), " ", "-")
+}
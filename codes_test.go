@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// weather codes 는 표의 코드를 한 줄씩 모두 보여주고, 매핑이 빠진 코드가 없어야 한다.
+func TestPrintCodesListsEveryCode(t *testing.T) {
+	out := captureStdout(t, printCodes)
+	lines := strings.Split(out, "\n")
+
+	for code := range wmoCodes {
+		prefix := padRight(fmt.Sprint(code), 5) + " "
+		found := false
+		for _, line := range lines {
+			if strings.HasPrefix(line, prefix) {
+				found = true
+				if strings.Contains(line, unknownCode.label) || !strings.HasSuffix(line, conditionSlug(code)) {
+					t.Errorf("code %d line incomplete: %q", code, line)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("code %d missing from output", code)
+		}
+	}
+	if want := fmt.Sprintf("%d개 코드, 아이콘 매핑 없음 0개", len(wmoCodes)); !strings.Contains(out, want) {
+		t.Errorf("summary line missing %q:\n%s", want, out)
+	}
+}
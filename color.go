@@ -0,0 +1,46 @@
+package main
+
+// ---------- Color ----------
+// 색은 대기질 등급 글자에만 쓴다. (좋음 파랑, 보통 초록, 나쁨 노랑, 매우 나쁨 빨강)
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// colorEnabled는 --color 와 $NO_COLOR 로 색 사용 여부를 정한다.
+//
+//  1. --color=always/never 를 명시하면 그대로 따른다.
+//  2. auto면 $NO_COLOR 가 비어 있지 않을 때 끈다. (https://no-color.org)
+//  3. 그 밖에는 터미널일 때만 켠다.
+func colorEnabled(flag string, getenv func(string) string, tty bool) bool {
+	switch flag {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+	if getenv("NO_COLOR") != "" {
+		return false
+	}
+	return tty
+}
+
+var gradeColors = map[string]string{
+	"좋음":    "\x1b[34m",
+	"양호":    "\x1b[36m",
+	"보통":    "\x1b[32m",
+	"나쁨":    "\x1b[33m",
+	"매우 나쁨": "\x1b[31m",
+	"위험":    "\x1b[35m",
+	"극히 나쁨": "\x1b[35m",
+}
+
+// colorGrade는 색이 켜져 있으면 text를 grade 색으로 감싼다.
+func (o Options) colorGrade(grade, text string) string {
+	c, ok := gradeColors[grade]
+	if !o.UseColor || !ok {
+		return text
+	}
+	return c + text + "\x1b[0m"
+}
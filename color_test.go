@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+// --color 를 명시하면 $NO_COLOR 보다 우선하고, auto면 $NO_COLOR 다음에 터미널 여부를 본다.
+func TestColorEnabled(t *testing.T) {
+	cases := []struct {
+		flag    string
+		noColor string
+		tty     bool
+		want    bool
+	}{
+		{colorAuto, "", true, true},
+		{colorAuto, "", false, false},
+		{colorAuto, "1", true, false},
+		{colorAuto, "0", true, false}, // 값과 상관없이 비어 있지 않으면 끈다.
+		{colorAlways, "1", false, true},
+		{colorAlways, "", false, true},
+		{colorNever, "", true, false},
+		{colorNever, "1", true, false},
+	}
+	for _, c := range cases {
+		getenv := func(k string) string {
+			if k == "NO_COLOR" {
+				return c.noColor
+			}
+			return ""
+		}
+		if got := colorEnabled(c.flag, getenv, c.tty); got != c.want {
+			t.Errorf("colorEnabled(%s, NO_COLOR=%q, tty=%v) = %v, want %v", c.flag, c.noColor, c.tty, got, c.want)
+		}
+	}
+}
+
+func TestColorGrade(t *testing.T) {
+	opts := testOptions()
+	if got := opts.colorGrade("나쁨", "나쁨"); got != "나쁨" {
+		t.Errorf("color off: got %q", got)
+	}
+	opts.UseColor = true
+	if got, want := opts.colorGrade("나쁨", "나쁨 😷"), "\x1b[33m나쁨 😷\x1b[0m"; got != want {
+		t.Errorf("color on: got %q, want %q", got, want)
+	}
+	if got := opts.colorGrade("알 수 없음", "?"); got != "?" {
+		t.Errorf("unknown grade colored: %q", got)
+	}
+}
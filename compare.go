@@ -0,0 +1,276 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ---------- compare 명령 ----------
+// weather compare <city> <city> [...] 는 여러 도시의 날씨/대기질을 표로 나란히 보여준다.
+// 도시별 Report는 reportCacheTTL 동안 캐시해, 다시 실행하면 만료된 도시만 새로 받는다.
+const (
+	reportCacheTTL   = 10 * time.Minute
+	maxCompareCities = 10
+
+	// --group-by 값 (빈 값이면 표)
+	groupByGrade = "grade"
+)
+
+// compareRow는 한 도시의 결과다. Cached면 캐시에서 읽은 Report다.
+type compareRow struct {
+	City   string
+	Report Report
+	Cached bool
+	Err    error
+}
+
+// RunCompare는 도시들을 동시에 조회해 표로 (--group-by=grade 면 AQI 등급별로) 출력한다.
+// 단위가 도시마다 달라지지 않도록 --unit=auto 는 섭씨로 본다. (--diff 와 같다)
+func RunCompare(ctx context.Context, cities []string, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	if opts.Unit == unitAuto {
+		opts.Unit = "c"
+	}
+	opts.BestEffort = true // 대기질만 실패한 도시도 기온은 보여준다.
+	opts.DryRun = false
+
+	rows := make([]compareRow, len(cities))
+	var g errgroup.Group
+	for i, city := range cities {
+		g.Go(catchPanic(func() error {
+			r, cached, err := cachedReport(ctx, client, city, opts)
+			rows[i] = compareRow{City: city, Report: r, Cached: cached, Err: err}
+			return nil
+		}))
+	}
+	rethrowPanic(g.Wait())
+
+	// --from 배치와 같이, 한 도시라도 실패하면 받은 도시는 출력하고 에러로 끝낸다.
+	be := &batchError{Total: len(rows)}
+	for _, row := range rows {
+		if row.Err != nil {
+			ce := &cityError{City: row.City, Err: row.Err}
+			fmt.Fprintf(os.Stderr, "error: %v\n", ce)
+			be.Failed = append(be.Failed, ce)
+		}
+	}
+	if len(be.Failed) == len(rows) {
+		errs := make([]error, len(be.Failed))
+		for i, ce := range be.Failed {
+			errs[i] = ce
+		}
+		return opts.deadlineErr(ctx, errors.Join(errs...))
+	}
+
+	if opts.GroupBy == groupByGrade {
+		printCompareByGrade(rows, opts)
+	} else {
+		printCompareTable(rows, opts)
+	}
+	return opts.deadlineErr(ctx, be.or(false))
+}
+
+// cachedReport는 캐시에 TTL 안의 Report가 있으면 그대로 (cached=true), 없으면 새로 받아 저장한다.
+// 날씨나 대기질 중 하나라도 실패한 Report는 저장하지 않는다.
+func cachedReport(ctx context.Context, client *http.Client, city string, opts Options) (r Report, cached bool, err error) {
+	cache := opts.cache()
+	key := reportCacheKey(city, opts)
+	if cache.get("report", key, reportCacheTTL, &r) {
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: report cache hit %s\n", key)
+		}
+		return r, true, nil
+	}
+
+	r, _, err = fetchReport(ctx, client, city, opts)
+	if err != nil {
+		return Report{}, false, err
+	}
+	if r.WeatherErr == nil && r.AirErr == nil {
+		if err := cache.put("report", key, r); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
+	return r, false, nil
+}
+
+// reportCacheKey는 Report가 달라지는 옵션 (언어, 단위, AQI 기준, 표시 이름, 좌표 반올림, 지오코더, 상세 단계)을
+// 도시 이름과 합친다.
+func reportCacheKey(city string, opts Options) string {
+	grid := "0"
+	if opts.PrivacyRound {
+		grid = fmt.Sprint(opts.PrivacyGrid)
+	}
+	return strings.Join([]string{
+		opts.lang(), opts.Unit, opts.AQIStandard, opts.Label, grid,
+		opts.GeocodeProvider, opts.GeocodeDB, fmt.Sprint(opts.DetailLevel),
+		strings.ToLower(strings.TrimSpace(city)),
+	}, "|")
+}
+
+// printCompareTable은 도시, 날씨, 기온, 체감, 대기질 열을 맞춰 출력한다. 캐시에서 읽은 도시는 (캐시)를 붙인다.
+// 머리줄과 등급 이름은 --lang, 대기질 열은 --aqi-standard 를 따른다. (eu 인데 EAQI가 없는 도시는 US AQI)
+//
+//	도시         날씨      기온     체감     대기질
+//	서울         ☀️ 맑음   12.3°C   11.0°C   보통 (AQI 62)
+//	부산 (캐시)  ☁️ 흐림   15.1°C   14.2°C   좋음 (AQI 35)
+func printCompareTable(rows []compareRow, opts Options) {
+	p, unit := opts.Precision, tempSymbol(opts)
+
+	cachedMark := opts.localizedInline(msg("cached"))
+	table := [][]string{{opts.text("city"), opts.text("weather"), opts.text("temp"), opts.text("feels"), opts.text("air")}}
+	for _, row := range rows {
+		if row.Err != nil {
+			continue
+		}
+		r := row.Report
+		name := r.Location.Name
+		if row.Cached {
+			name += " (" + cachedMark + ")"
+		}
+		cond, temp, feels := "--", "--", "--"
+		if r.WeatherErr == nil {
+			w := r.Weather
+			cond = conditionText(w.WeatherCode, w.isNight(r.Time), opts)
+			temp = p.format("temp", w.Temperature2m) + unit
+			feels = p.format("temp", w.ApparentTemperature) + unit
+		}
+		air, std := "--", opts
+		v, ok := compareAQI(row, std)
+		if !ok && r.AirErr == nil {
+			std.AQIStandard = aqiStandardUS
+			v, ok = compareAQI(row, std)
+		}
+		if ok {
+			air = fmt.Sprintf("%s (%s %d)", compareGradeName(v, std), compareScale(std), v)
+		}
+		table = append(table, []string{name, cond, temp, feels, air})
+	}
+
+	widths := make([]int, len(table[0]))
+	for _, cells := range table {
+		for i, c := range cells {
+			widths[i] = max(widths[i], displayWidth(c))
+		}
+	}
+	for _, cells := range table {
+		var b strings.Builder
+		for i, c := range cells {
+			if i == len(cells)-1 {
+				b.WriteString(c)
+				break
+			}
+			b.WriteString(padRight(c, widths[i]+2))
+		}
+		fmt.Println(b.String())
+	}
+}
+
+// printCompareByGrade는 --group-by=grade 출력이다. --aqi-standard 기준의 등급 순으로 묶고, 등급 안에서는 지수 낮은 순이다.
+// 대기질을 못 받은 도시 (eu 기준이면 EAQI가 없는 도시도)는 마지막 "정보 없음" 묶음에 넣는다.
+// 등급 이름은 --lang 을 따른다.
+//
+//	좋음
+//	  부산 (AQI 35)
+//	보통
+//	  서울 (AQI 62)
+func printCompareByGrade(rows []compareRow, opts Options) {
+	namesKR, namesEN, cutoffs := compareGrades(opts)
+	scale := compareScale(opts)
+
+	noData := len(namesKR)
+	cachedMark := opts.localizedInline(msg("cached"))
+	groups := make([][]compareRow, noData+1)
+	for _, row := range rows {
+		grade := noData
+		if v, ok := compareAQI(row, opts); ok {
+			grade = gradeIndex(float64(v), cutoffs)
+		}
+		groups[grade] = append(groups[grade], row)
+	}
+
+	for grade, members := range groups {
+		if len(members) == 0 {
+			continue
+		}
+		slices.SortStableFunc(members, func(a, b compareRow) int {
+			va, _ := compareAQI(a, opts)
+			vb, _ := compareAQI(b, opts)
+			return cmp.Compare(va, vb)
+		})
+
+		if grade == noData {
+			fmt.Println(opts.text("no_data"))
+		} else {
+			fmt.Println(opts.localized(namesKR[grade], namesEN[grade]))
+		}
+		for _, row := range members {
+			name := row.City
+			if row.Err == nil {
+				name = row.Report.Location.Name
+			}
+			if row.Cached {
+				name += " (" + cachedMark + ")"
+			}
+			if grade == noData {
+				fmt.Println("  " + name)
+				continue
+			}
+			v, _ := compareAQI(row, opts)
+			fmt.Printf("  %s (%s %d)\n", name, scale, v)
+		}
+	}
+}
+
+// compareGrades는 --aqi-standard 의 등급 이름 (한국어, 영어)과 구간 상한이다.
+func compareGrades(opts Options) (namesKR, namesEN []string, cutoffs []float64) {
+	if opts.AQIStandard == aqiStandardEU {
+		return aqiNamesEUKR, aqiNamesEU, euAQICutoffs
+	}
+	return aqiNamesKR, aqiNamesEN, thresholds.AQI
+}
+
+// compareScale은 지수 옆에 붙이는 이름이다. (AQI, EAQI)
+func compareScale(opts Options) string {
+	if opts.AQIStandard == aqiStandardEU {
+		return "EAQI"
+	}
+	return "AQI"
+}
+
+// compareGradeName은 지수 v의 등급 이름을 --lang 으로 고른다.
+func compareGradeName(v int, opts Options) string {
+	namesKR, namesEN, cutoffs := compareGrades(opts)
+	i := gradeIndex(float64(v), cutoffs)
+	return opts.localized(namesKR[i], namesEN[i])
+}
+
+// compareAQI는 row의 --aqi-standard 기준 지수다. 대기질이 없거나 eu 기준인데 EAQI가 없으면 ok=false다.
+func compareAQI(row compareRow, opts Options) (aqi int, ok bool) {
+	if row.Err != nil || row.Report.AirErr != nil {
+		return 0, false
+	}
+	if opts.AQIStandard == aqiStandardEU {
+		if row.Report.Air.AQIEU == nil {
+			return 0, false
+		}
+		return *row.Report.Air.AQIEU, true
+	}
+	return aqiOf(row.Report.Air), true
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func compareRows() []compareRow {
+	row := func(name string, us, eu int) compareRow {
+		r := Report{Location: GeoResult{Name: name}}
+		r.Air = AirQualityCurrent{AQIUS: ptr(us), AQIEU: ptr(eu)}
+		return compareRow{City: name, Report: r}
+	}
+	noEU := row("광주", 120, 0)
+	noEU.Report.Air.AQIEU = nil
+	failed := row("대구", 0, 0)
+	failed.Report.AirErr = errors.New("timeout")
+	cached := row("부산", 35, 45)
+	cached.Cached = true
+	return []compareRow{row("서울", 62, 30), cached, noEU, row("인천", 40, 15), failed}
+}
+
+func TestPrintCompareByGrade(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(*Options)
+		want string
+	}{
+		{"us ko", func(o *Options) {}, `좋음
+  부산 (캐시) (AQI 35)
+  인천 (AQI 40)
+보통
+  서울 (AQI 62)
+나쁨
+  광주 (AQI 120)
+정보 없음
+  대구
+`},
+		{"eu en", func(o *Options) { o.AQIStandard, o.Lang = aqiStandardEU, "en" }, `Good
+  인천 (EAQI 15)
+Fair
+  서울 (EAQI 30)
+Moderate
+  부산 (cached) (EAQI 45)
+no data
+  광주
+  대구
+`},
+		{"us ko,en", func(o *Options) { o.DualLang = true }, `좋음 (good)
+  부산 (캐시/cached) (AQI 35)
+  인천 (AQI 40)
+보통 (moderate)
+  서울 (AQI 62)
+나쁨 (unhealthy for sensitive groups)
+  광주 (AQI 120)
+정보 없음 (no data)
+  대구
+`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions()
+			tt.opts(&opts)
+			got := captureStdout(t, func() { printCompareByGrade(compareRows(), opts) })
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintCompareTable(t *testing.T) {
+	rows := compareRows()
+	rows[0].Report.Weather = Current{Temperature2m: 12.3, ApparentTemperature: 11}
+
+	opts := testOptions()
+	opts.AQIStandard, opts.Lang, opts.IconSet = aqiStandardEU, "en", iconSetASCII
+	got := captureStdout(t, func() { printCompareTable(rows, opts) })
+	want := []string{
+		"city           weather    temperature  feels like  air quality",
+		"서울           [*]  맑음  12.3°C       11.0°C      Fair (EAQI 30)",
+		"부산 (cached)  [*]  맑음  0.0°C        0.0°C       Moderate (EAQI 45)",
+		"광주           [*]  맑음  0.0°C        0.0°C       unhealthy for sensitive groups (AQI 120)",
+		"인천           [*]  맑음  0.0°C        0.0°C       Good (EAQI 15)",
+		"대구           [*]  맑음  0.0°C        0.0°C       --",
+	}
+	if got != strings.Join(want, "\n")+"\n" {
+		t.Errorf("got:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestReportCacheKey(t *testing.T) {
+	base := testOptions()
+	key := reportCacheKey("Seoul", base)
+	if got := reportCacheKey(" seoul ", base); got != key {
+		t.Errorf("city case/space changed the key: %q vs %q", got, key)
+	}
+	for name, change := range map[string]func(*Options){
+		"lang":     func(o *Options) { o.Lang = "en" },
+		"unit":     func(o *Options) { o.Unit = "f" },
+		"aqi":      func(o *Options) { o.AQIStandard = aqiStandardEU },
+		"label":    func(o *Options) { o.Label = "우리 집" },
+		"privacy":  func(o *Options) { o.PrivacyRound, o.PrivacyGrid = true, 0.1 },
+		"provider": func(o *Options) { o.GeocodeProvider = "local" },
+		"detail":   func(o *Options) { o.DetailLevel = detailAll },
+	} {
+		opts := base
+		change(&opts)
+		if reportCacheKey("Seoul", opts) == key {
+			t.Errorf("%s does not change the cache key", name)
+		}
+	}
+}
+
+// 캐시가 살아 있는 도시는 다시 받지 않고, 만료된 도시와 없는 도시만 받는다.
+func TestRunCompareFetchesOnlyStale(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	srv := fakeOpenMeteo(t, map[string]GeoResult{
+		"서울": {Name: "서울", Latitude: 37.5, Longitude: 127},
+		"부산": {Name: "부산", Latitude: 35.1, Longitude: 129},
+		"인천": {Name: "인천", Latitude: 37.4, Longitude: 126.7},
+	})
+	var mu sync.Mutex
+	geocoded := map[string]int{}
+	inner := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/search" {
+			mu.Lock()
+			geocoded[r.URL.Query().Get("name")]++
+			mu.Unlock()
+		}
+		inner.ServeHTTP(w, r)
+	})
+
+	opts := testOptions()
+	opts.NoCache, opts.CacheDir = false, t.TempDir()
+	cache := opts.cache()
+	seed := func(city string, at time.Time) {
+		fixNow(t, at)
+		r := testReport()
+		r.Location.Name = city
+		if err := cache.put("report", reportCacheKey(city, opts), r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	seed("서울", now.Add(-time.Minute))
+	seed("부산", now.Add(-reportCacheTTL-time.Minute))
+	fixNow(t, now)
+
+	out := captureStdout(t, func() {
+		if err := RunCompare(context.Background(), []string{"서울", "부산", "인천"}, opts); err != nil {
+			t.Errorf("RunCompare: %v", err)
+		}
+	})
+	if geocoded["서울"] != 0 || geocoded["부산"] != 1 || geocoded["인천"] != 1 {
+		t.Errorf("geocode requests = %v, want only 부산 and 인천 once", geocoded)
+	}
+	if !strings.Contains(out, "서울 (캐시)") || strings.Contains(out, "부산 (캐시)") {
+		t.Errorf("cached marker wrong:\n%s", out)
+	}
+}
+
+// 일부 도시만 실패해도 받은 도시는 출력하고 에러로 끝난다. (--from 과 같은 종료 코드)
+func TestRunComparePartialFailure(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	fakeOpenMeteo(t, map[string]GeoResult{"서울": {Name: "서울", Latitude: 37.5, Longitude: 127}})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = RunCompare(context.Background(), []string{"서울", "nowhere"}, testOptions())
+	})
+	var be *batchError
+	if !errors.As(err, &be) || len(be.Failed) != 1 || be.Failed[0].City != "nowhere" {
+		t.Fatalf("err = %v, want batchError for nowhere", err)
+	}
+	if !strings.Contains(out, "서울") {
+		t.Errorf("successful city missing from output:\n%s", out)
+	}
+
+	if err := RunCompare(context.Background(), []string{"nowhere"}, testOptions()); err == nil {
+		t.Error("all cities failed but RunCompare returned nil")
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ---------- compare-air 명령 ----------
+// airSide는 compare-air 한쪽 도시의 결과다. Err가 있으면 AQ는 비어 있다.
+type airSide struct {
+	Name string
+	AQ   AirQualityCurrent
+	Err  error
+}
+
+// RunCompareAir는 두 도시의 대기질을 동시에 받아 어느 쪽이 깨끗한지 한 줄로 출력한다.
+func RunCompareAir(ctx context.Context, cityA, cityB string, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	sides := fetchPair(cityA, cityB, func(city string) airSide {
+		s := airSide{}
+		s.Name, s.AQ, s.Err = fetchCityAir(ctx, client, city, opts)
+		return s
+	})
+
+	if sides[0].Err != nil && sides[1].Err != nil {
+		return opts.deadlineErr(ctx, errors.Join(sides[0].Err, sides[1].Err))
+	}
+	for _, s := range sides {
+		if s.Err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %s: %v\n", s.Name, s.Err)
+		}
+	}
+	fmt.Println(airVerdict(sides[0], sides[1], opts))
+	return nil
+}
+
+// fetchPair는 두 도시를 동시에 조회한다. 한쪽이 실패해도 다른 쪽은 끝까지 받는다. (compare-air, --diff)
+func fetchPair[T any](cityA, cityB string, fetch func(city string) T) [2]T {
+	var (
+		out [2]T
+		g   errgroup.Group
+	)
+	for i, city := range []string{cityA, cityB} {
+		g.Go(catchPanic(func() error {
+			out[i] = fetch(city)
+			return nil
+		}))
+	}
+	rethrowPanic(g.Wait())
+	return out
+}
+
+// fetchCityAir는 지오코딩 후 대기질을 받는다. 이름은 지오코딩 결과의 이름이다.
+func fetchCityAir(ctx context.Context, client *http.Client, city string, opts Options) (string, AirQualityCurrent, error) {
+	loc, err := resolveCity(ctx, client, city, opts)
+	if err != nil {
+		return city, AirQualityCurrent{}, err
+	}
+
+	actx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.AirTimeout))
+	defer cancel()
+
+	q := airQuery(opts, false)
+	aq, err := cachedByGrid(opts, "air", loc.Latitude, loc.Longitude, q, func() (AirQualityCurrent, error) {
+		return fetchAirQuality(actx, client, loc.Latitude, loc.Longitude, q)
+	})
+	return loc.Name, clampNegativeAir(aq, opts), err
+}
+
+// airVerdict는 "부산이 서울보다 공기가 좋음 (AQI 28 vs 51, PM2.5 12.0 vs 30.0)" 같은 한 줄 판정이다.
+// AQI가 같으면 비슷함, 한쪽이 없으면 있는 쪽 값만 보여준다.
+func airVerdict(a, b airSide, opts Options) string {
+	switch {
+	case a.Err != nil:
+		return fmt.Sprintf("%s 대기질 정보 없음 (%s AQI %d)", a.Name, b.Name, aqiOf(b.AQ))
+	case b.Err != nil:
+		return fmt.Sprintf("%s 대기질 정보 없음 (%s AQI %d)", b.Name, a.Name, aqiOf(a.AQ))
+	}
+
+	p := opts.Precision
+	aqiA, aqiB := aqiOf(a.AQ), aqiOf(b.AQ)
+	if aqiA == aqiB {
+		return fmt.Sprintf("%s, %s 공기가 비슷함 (AQI %d, PM2.5 %s vs %s)",
+			a.Name, b.Name, aqiA, p.format("pm25", a.AQ.PM25), p.format("pm25", b.AQ.PM25))
+	}
+
+	better, worse := a, b
+	if aqiB < aqiA {
+		better, worse = b, a
+	}
+	return fmt.Sprintf("%s%s %s보다 공기가 좋음 (AQI %d vs %d, PM2.5 %s vs %s)",
+		better.Name, subjectParticle(better.Name), worse.Name,
+		aqiOf(better.AQ), aqiOf(worse.AQ),
+		p.format("pm25", better.AQ.PM25), p.format("pm25", worse.AQ.PM25))
+}
+
+func aqiOf(aq AirQualityCurrent) int {
+	aqi, _ := aq.usAQI()
+	return aqi
+}
+
+// subjectParticle은 이름 끝 글자에 받침이 있으면 "이", 없으면 "가"를 돌려준다.
+// 한글로 끝나지 않으면 "이(가)"다.
+func subjectParticle(name string) string {
+	r := []rune(name)
+	if len(r) == 0 {
+		return "이(가)"
+	}
+	last := r[len(r)-1]
+	if last < 0xAC00 || last > 0xD7A3 {
+		return "이(가)"
+	}
+	if (last-0xAC00)%28 == 0 {
+		return "가"
+	}
+	return "이"
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ---------- Config ----------
+// Config는 flag 기본값을 덮어쓰는 사용자 설정이다.
+// 위치: --profile-file > $WEATHER_CONFIG > <UserConfigDir>/weather-cli/config.json
+type Config struct {
+	City string `json:"city,omitempty"`
+	Unit string `json:"unit,omitempty"` // "c" | "f"
+	Lang string `json:"lang,omitempty"` // "ko" | "en"
+
+	Profiles map[string]Profile `json:"profiles,omitempty"`
+}
+
+// configPath는 file (--profile-file) > $WEATHER_CONFIG > 기본 위치 순으로 설정 파일 경로를 정한다.
+// explicit는 앞의 둘로 직접 지정한 경로인지다. (없으면 기본값으로 넘어가지 않고 에러)
+func configPath(file string) (path string, explicit bool, err error) {
+	if file != "" {
+		return file, true, nil
+	}
+	if env := os.Getenv("WEATHER_CONFIG"); env != "" {
+		return env, true, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", false, fmt.Errorf("config dir: %w", err)
+	}
+	return filepath.Join(dir, "weather-cli", "config.json"), false, nil
+}
+
+// profileFileFlag는 --profile-file 값을 dst에 넣는다. --profile-file= 처럼 빈 값은 거절한다.
+func profileFileFlag(dst *string) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			return errors.New("empty path")
+		}
+		*dst = s
+		return nil
+	}
+}
+
+// scanProfileFile은 flag 파싱 전에 args에서 --profile-file 값을 찾는다.
+// flag 기본값을 설정에서 가져오므로 먼저 봐야 한다. fs로 값을 받는 flag를 알아내 그 값은 건너뛰고, -- 뒤는 보지 않는다.
+func scanProfileFile(fs *flag.FlagSet, args []string) (string, error) {
+	var file string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			break
+		}
+		if len(a) < 2 || a[0] != '-' {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(a, "-"), "=")
+		f := fs.Lookup(name)
+		if f == nil {
+			continue // 모르는 flag는 실제 파싱에서 알린다.
+		}
+		if name == "profile-file" {
+			if !hasValue {
+				if i+1 >= len(args) {
+					break
+				}
+				i++
+				value = args[i]
+			}
+			if value == "" {
+				return "", errors.New("--profile-file requires a path")
+			}
+			file = value // 여러 번 주면 flag 패키지처럼 마지막 값
+			continue
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !hasValue && !(ok && b.IsBoolFlag()) {
+			i++ // 이 flag의 값
+		}
+	}
+	return file, nil
+}
+
+// loadConfigFile은 path의 설정을 읽는다. explicit인데 파일이 없으면 기본값으로 넘어가지 않고 에러다.
+func loadConfigFile(path string, explicit bool) (Config, error) {
+	if explicit {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return Config{}, fmt.Errorf("config file %s not found (--profile-file, $WEATHER_CONFIG)", path)
+		}
+	}
+	return loadConfig(path)
+}
+
+// loadConfig는 설정 파일이 없으면 빈 Config를 돌려준다.
+func loadConfig(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("config read failed: %w", err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Config{}, fmt.Errorf("config parse failed (%s): %w", path, err)
+	}
+	if err := c.validate(); err != nil {
+		return Config{}, fmt.Errorf("config %s: %w", path, err)
+	}
+	return c, nil
+}
+
+func saveConfig(path string, c Config) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("config encode failed: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("config dir create failed: %w", err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("config write failed: %w", err)
+	}
+	return nil
+}
+
+func (c Config) validate() error {
+	if c.Unit != "" && !validUnit(c.Unit) {
+		return fmt.Errorf("invalid unit %q (c, f, auto)", c.Unit)
+	}
+	if c.Lang != "" && !validLang(c.Lang) {
+		return fmt.Errorf("invalid lang %q (ko, en)", c.Lang)
+	}
+	for name, p := range c.Profiles {
+		if !validProfileName(name) {
+			return fmt.Errorf("invalid profile name %q (a-z, 0-9, -, _)", name)
+		}
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func validUnit(u string) bool { return u == "c" || u == "f" || u == unitAuto }
+func validLang(l string) bool { return l == "ko" || l == "en" }
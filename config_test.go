@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScanProfileFile(t *testing.T) {
+	cases := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"seoul", "--profile-file", "a.json"}, "a.json"},
+		{[]string{"--profile-file=a.json", "seoul"}, "a.json"},
+		{[]string{"-profile-file", "a.json"}, "a.json"},
+		{[]string{"--verbose", "--profile-file", "a.json"}, "a.json"},                // bool flag는 값을 먹지 않는다.
+		{[]string{"--format", "--profile-file", "seoul"}, ""},                        // --format 의 값이다.
+		{[]string{"--unit", "f", "seoul", "--", "--profile-file", "a.json"}, ""},     // -- 뒤는 flag가 아니다.
+		{[]string{"--profile-file", "a.json", "--profile-file", "b.json"}, "b.json"}, // 마지막 값
+		{[]string{"seoul"}, ""},
+	}
+	for _, c := range cases {
+		var opts Options
+		fs := newFlagSet("weather", &opts)
+		fs.StringVar(&opts.Format, "format", "", "")
+		got, err := scanProfileFile(fs, c.args)
+		if err != nil || got != c.want {
+			t.Errorf("scanProfileFile(%q) = %q, %v; want %q", c.args, got, err, c.want)
+		}
+	}
+
+	var opts Options
+	fs := newFlagSet("weather", &opts)
+	for _, args := range [][]string{{"--profile-file="}, {"--profile-file", ""}} {
+		if _, err := scanProfileFile(fs, args); err == nil {
+			t.Errorf("scanProfileFile(%q) accepted an empty path", args)
+		}
+	}
+}
+
+func TestConfigPathOverride(t *testing.T) {
+	t.Setenv("WEATHER_CONFIG", "/env/config.json")
+
+	if path, explicit, err := configPath("/flag/config.json"); err != nil || path != "/flag/config.json" || !explicit {
+		t.Errorf("--profile-file: configPath = %q, %v, %v", path, explicit, err)
+	}
+	if path, explicit, err := configPath(""); err != nil || path != "/env/config.json" || !explicit {
+		t.Errorf("$WEATHER_CONFIG: configPath = %q, %v, %v", path, explicit, err)
+	}
+
+	t.Setenv("WEATHER_CONFIG", "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	t.Setenv("HOME", "/home/test")
+	if path, explicit, err := configPath(""); err != nil || explicit || !strings.HasSuffix(path, filepath.Join("weather-cli", "config.json")) {
+		t.Errorf("default: configPath = %q, %v, %v", path, explicit, err)
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"city":"부산","unit":"f"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfigFile(path, true)
+	if err != nil || cfg.City != "부산" || cfg.Unit != "f" {
+		t.Errorf("loadConfigFile = %+v, %v", cfg, err)
+	}
+
+	// 설정 값은 flag 기본값이 되고, 명령줄 값이 이긴다.
+	var opts Options
+	fs := newFlagSet("weather", &opts)
+	applyConfigDefaults(fs, cfg)
+	if opts.Unit != "f" {
+		t.Errorf("unit default = %q, want f", opts.Unit)
+	}
+	parseArgs(fs, []string{"--unit", "c"})
+	if opts.Unit != "c" {
+		t.Errorf("unit = %q, want c from the command line", opts.Unit)
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	if _, err := loadConfigFile(missing, true); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("explicit missing file: err = %v, want not found", err)
+	}
+	if cfg, err := loadConfigFile(missing, false); err != nil || cfg.City != "" {
+		t.Errorf("default missing file: %+v, %v; want empty config", cfg, err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfigFile(path, true); err == nil {
+		t.Error("broken config file was accepted")
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ---------- Coordinates (--coords) ----------
+// --coords "lat,lon" 을 주면 지오코딩 없이 그 좌표로 바로 조회한다. (--dry-run 과 함께 쓰면 네트워크 호출이 없다)
+// 이름은 좌표 그대로이고, --label 로 바꿀 수 있다.
+
+// parseCoords는 "37.5665,126.978" 을 검사해 GeoResult로 바꾼다. 빈 값이면 nil이다.
+func parseCoords(s string) (*GeoResult, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	latStr, lonStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return nil, fmt.Errorf("invalid --coords %q (lat,lon, 예: 37.5665,126.978)", s)
+	}
+	lat, err1 := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	lon, err2 := strconv.ParseFloat(strings.TrimSpace(lonStr), 64)
+	if err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("invalid --coords %q (lat,lon, 예: 37.5665,126.978)", s)
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("invalid --coords %q (위도 -90 ~ 90, 경도 -180 ~ 180)", s)
+	}
+	return &GeoResult{
+		Name:      strconv.FormatFloat(lat, 'f', -1, 64) + ", " + strconv.FormatFloat(lon, 'f', -1, 64),
+		Latitude:  lat,
+		Longitude: lon,
+	}, nil
+}
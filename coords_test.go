@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseCoords(t *testing.T) {
+	loc, err := parseCoords(" 37.5665 , 126.978 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Latitude != 37.5665 || loc.Longitude != 126.978 || loc.Name != "37.5665, 126.978" {
+		t.Errorf("parseCoords = %+v", loc)
+	}
+
+	if loc, err := parseCoords(""); loc != nil || err != nil {
+		t.Errorf("parseCoords(\"\") = %v, %v; want nil, nil", loc, err)
+	}
+	for _, in := range []string{"37.5", "a,b", "91,0", "0,181", "37.5,126.9,1"} {
+		if _, err := parseCoords(in); err == nil {
+			t.Errorf("parseCoords(%q) succeeded, want error", in)
+		}
+	}
+}
+
+// --coords 면 지오코딩 API를 부르지 않는다.
+func TestDryRunWithCoords(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer srv.Close()
+	orig := endpoints
+	endpoints = endpointURLs{Geocode: []string{srv.URL}, Forecast: []string{forecastBaseURL}, Air: []string{airBaseURL}}
+	defer func() { endpoints = orig }()
+
+	opts := testOptions()
+	opts.DryRun = true
+	opts.Coords, _ = parseCoords("37.5665,126.978")
+
+	var err error
+	out := captureStdout(t, func() { err = RunNow(context.Background(), "", opts) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"latitude=37.5665", "longitude=126.978", forecastBaseURL, airBaseURL} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// --deadline 은 엔드포인트 타임아웃이 넉넉해도 전체 실행을 끊고, 그렇다고 알려 준다.
+func TestDeadlineTrips(t *testing.T) {
+	srv := fakeOpenMeteo(t, map[string]GeoResult{"seoul": {ID: 1, Name: "서울", Latitude: 37.566, Longitude: 126.9784}})
+	slowPath(t, srv, "/v1/forecast")
+	opts := testOptions()
+	opts.Timeout = 5 * time.Second
+	opts.Deadline = 100 * time.Millisecond
+
+	start := time.Now()
+	var err error
+	out := captureStdout(t, func() { err = RunNow(context.Background(), "seoul", opts) })
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("took %s, deadline did not fire", elapsed)
+	}
+	if err == nil {
+		t.Fatal("expected deadline error")
+	}
+	if !strings.HasPrefix(err.Error(), "deadline 100ms exceeded (--deadline): ") {
+		t.Errorf("err = %q, want deadline wording", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded in chain", err)
+	}
+	if out != "" {
+		t.Errorf("printed %q on deadline, want nothing", out)
+	}
+}
+
+// 전체 deadline 으로 실패하면 exit 1 로 끝난다. 기본은 타임아웃 안내, --debug-errors 면 deadline 문구 그대로다.
+// (failOnRunError가 os.Exit 하므로 하위 프로세스로 본다)
+func TestDeadlineExitCode(t *testing.T) {
+	if mode := os.Getenv("WEATHER_TEST_DEADLINE_CHILD"); mode != "" {
+		srv := fakeOpenMeteo(t, map[string]GeoResult{"seoul": {ID: 1, Name: "서울", Latitude: 37.566, Longitude: 126.9784}})
+		slowPath(t, srv, "/v1/forecast")
+		opts := testOptions()
+		opts.Timeout = 5 * time.Second
+		opts.Deadline = 100 * time.Millisecond
+		opts.DebugErrors = mode == "debug"
+		failOnRunError(RunNow(context.Background(), "seoul", opts), opts)
+		return
+	}
+
+	cases := []struct{ mode, want string }{
+		{"friendly", "error: " + friendlyTimeout},
+		{"debug", "error: failed: deadline 100ms exceeded (--deadline): "},
+	}
+	for _, c := range cases {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestDeadlineExitCode$")
+		cmd.Env = append(os.Environ(), "WEATHER_TEST_DEADLINE_CHILD="+c.mode, "WEATHER_DEBUG=")
+		var stderr strings.Builder
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+			t.Errorf("%s: exit = %v, want exit status 1 (stderr: %s)", c.mode, err, stderr.String())
+			continue
+		}
+		if !strings.Contains(stderr.String(), c.want) {
+			t.Errorf("%s: stderr = %q, want %q", c.mode, stderr.String(), c.want)
+		}
+	}
+}
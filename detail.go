@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ---------- Detail level ----------
+// --detail-level 은 섹션 묶음 프리셋이다. 개별 flag(--moon 등)는 그대로 함께 쓸 수 있다.
+//
+//	0  한 줄 요약 (도시, 날씨, 기온, 대기질)
+//	1  기본: 머리줄, 날씨/가시거리, 바람, 대기질
+//	2  1 + 습도, 자외선, 오늘 최저/최고
+//	3  2 + 오존 등 가스 오염물질, 일출/일몰, 달 위상
+const (
+	detailOneLine = 0
+	detailDefault = 1
+	detailExtra   = 2
+	detailAll     = 3
+)
+
+// printOneLine은 --detail-level 0 출력이다.
+// 예: 서울 | ☀️ 맑음 18.2°C | 대기질 좋음
+func printOneLine(r Report, opts Options) {
+	line := r.Location.Name
+	if r.WeatherErr == nil {
+		w := r.Weather
+		line += fmt.Sprintf(" | %s %s%s",
+			opts.dual(conditionText(w.WeatherCode, w.isNight(r.Time), opts), conditionEN(w.WeatherCode)),
+			opts.Precision.format("temp", w.Temperature2m), tempSymbol(opts))
+	}
+	if r.AirErr == nil {
+		aqi, _ := r.Air.usAQI()
+		line += " | " + opts.label("air") + " " + opts.dual(aqiLabel(aqi), aqiStatusEN(aqi))
+	}
+	fmt.Println(line)
+}
+
+// printExtraWeather는 --detail-level 2 이상에서 습도, 자외선, 오늘 최저/최고를 한 줄로 출력한다.
+func printExtraWeather(w Current, opts Options) {
+	p := opts.Precision
+	unit := tempSymbol(opts)
+
+	humidity := "--"
+	if w.Humidity != nil {
+		humidity = p.format("humidity", *w.Humidity) + "%"
+	}
+	uv := "--"
+	if w.UVIndex != nil {
+		uv = p.format("uv", *w.UVIndex)
+	}
+	line := fmt.Sprintf("%s %s | %s %s", opts.label("humidity"), humidity, opts.label("uv"), uv)
+	if w.TodayMin != nil && w.TodayMax != nil {
+		line += fmt.Sprintf(" | %s %s%s ~ %s%s", opts.label("today"), p.format("temp", *w.TodayMin), unit, p.format("temp", *w.TodayMax), unit)
+	}
+	fmt.Println(line)
+}
+
+// printGases는 --detail-level 3 에서 PM 외 가스 오염물질을 출력한다.
+func printGases(aq AirQualityCurrent, opts Options) {
+	for _, p := range pollutants(aq) {
+		if p.Field == "pm10" || p.Field == "pm25" {
+			continue
+		}
+		fmt.Printf("%s %s%s %s\n", p.Label, opts.Precision.format(p.Field, p.Value), p.Unit, p.Grade)
+	}
+}
+
+// printSunTimes는 오늘 일출/일몰을 위치의 현지 시각으로 출력한다. 모르면 아무것도 출력하지 않는다.
+// KST가 아닌 곳이면 헤더의 (KST) 시각과 헷갈리지 않게 (현지 시각)을 붙인다.
+func printSunTimes(w Current) {
+	if w.Sunrise.IsZero() || w.Sunset.IsZero() {
+		return
+	}
+	fmt.Println(sunTimesText(w))
+}
+
+func sunTimesText(w Current) string {
+	s := fmt.Sprintf("일출 %s | 일몰 %s", w.Sunrise.Format("15:04"), w.Sunset.Format("15:04"))
+	if _, off := w.Sunrise.Zone(); off != kstOffset {
+		s += " (현지 시각)"
+	}
+	return s
+}
+
+// daylightText는 --since-sunrise 문구다. 일출/일몰을 모르면 "" 이다.
+//
+//	낮:      "낮 시간 62% 경과 (일몰까지 3시간 41분)"
+//	일출 전: "일출까지 1시간 20분"
+//	일몰 후: "해가 졌습니다 (내일 일출까지 9시간 5분)" (내일 일출을 모르면 일몰 후 경과 시간)
+func daylightText(w Current, now time.Time) string {
+	if w.Sunrise.IsZero() || w.Sunset.IsZero() || !w.Sunset.After(w.Sunrise) {
+		return ""
+	}
+	switch {
+	case now.Before(w.Sunrise):
+		return "일출까지 " + formatHM(w.Sunrise.Sub(now))
+	case now.Before(w.Sunset):
+		pct := int(now.Sub(w.Sunrise) * 100 / w.Sunset.Sub(w.Sunrise))
+		return fmt.Sprintf("낮 시간 %d%% 경과 (일몰까지 %s)", pct, formatHM(w.Sunset.Sub(now)))
+	case w.NextSunrise.After(now):
+		return "해가 졌습니다 (내일 일출까지 " + formatHM(w.NextSunrise.Sub(now)) + ")"
+	default:
+		return "해가 졌습니다 (일몰 후 " + formatHM(now.Sub(w.Sunset)) + ")"
+	}
+}
+
+// formatHM은 "3시간 41분", 1시간 미만이면 "41분" 이다. (분 미만 버림)
+func formatHM(d time.Duration) string {
+	m := int(d / time.Minute)
+	if m < 60 {
+		return fmt.Sprintf("%d분", m)
+	}
+	return fmt.Sprintf("%d시간 %d분", m/60, m%60)
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// laResponse는 timezone=auto 로 받은 로스앤젤레스 응답이다. (2025-03-14, PDT = UTC-7)
+func laResponse(t *testing.T, tz string) OpenMeteoResponse {
+	t.Helper()
+	var data OpenMeteoResponse
+	body := `{"timezone":"` + tz + `","timezone_abbreviation":"PDT","utc_offset_seconds":-25200,
+		"current":{"temperature_2m":18,"weather_code":0},
+		"daily":{"sunrise":["2025-03-14T07:07","2025-03-15T07:05"],"sunset":["2025-03-14T19:03","2025-03-15T19:04"]}}`
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDaylightTextLocalTimezone(t *testing.T) {
+	pdt := time.FixedZone("PDT", -7*60*60)
+	cases := []struct {
+		name string
+		now  time.Time // 현지 시각 (KST로는 다음 날일 수도 있다)
+		want string
+	}{
+		{"before sunrise", time.Date(2025, 3, 14, 5, 0, 0, 0, pdt), "일출까지 2시간 7분"},
+		{"daytime", time.Date(2025, 3, 14, 16, 0, 0, 0, pdt), "낮 시간 74% 경과 (일몰까지 3시간 3분)"},
+		{"after sunset", time.Date(2025, 3, 14, 22, 0, 0, 0, pdt), "해가 졌습니다 (내일 일출까지 9시간 5분)"},
+	}
+	// tzdata가 있든 없든 (utc_offset_seconds로) 같아야 한다.
+	for _, tz := range []string{"America/Los_Angeles", "Nowhere/Unknown"} {
+		w, err := currentFromResponse(laResponse(t, tz), queryOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !w.Sunset.After(w.Sunrise) {
+			t.Fatalf("tz=%s: sunset %s is not after sunrise %s", tz, w.Sunset, w.Sunrise)
+		}
+		for _, c := range cases {
+			// 출력 쪽은 조회 시각을 KST로 들고 있다.
+			if got := daylightText(w, c.now.In(kst)); got != c.want {
+				t.Errorf("tz=%s %s: daylightText = %q, want %q", tz, c.name, got, c.want)
+			}
+		}
+	}
+}
+
+func TestSunTimesTextLocalClock(t *testing.T) {
+	w, err := currentFromResponse(laResponse(t, "America/Los_Angeles"), queryOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sunTimesText(w), "일출 07:07 | 일몰 19:03 (현지 시각)"; got != want {
+		t.Errorf("sunTimesText = %q, want %q", got, want)
+	}
+
+	seoul := Current{
+		Sunrise: time.Date(2025, 3, 14, 6, 45, 0, 0, kst),
+		Sunset:  time.Date(2025, 3, 14, 18, 35, 0, 0, kst),
+	}
+	if got, want := sunTimesText(seoul), "일출 06:45 | 일몰 18:35"; got != want {
+		t.Errorf("sunTimesText = %q, want %q", got, want)
+	}
+}
+
+func TestForecastQueryUsesLocationTimezone(t *testing.T) {
+	u, err := url.Parse(buildForecastURL(forecastBaseURL, 34.05, -118.24, forecastQuery(Options{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tz := u.Query().Get("timezone"); tz != "auto" {
+		t.Errorf("timezone = %q, want auto", tz)
+	}
+	if !strings.Contains(u.Query().Get("daily"), "sunset") {
+		t.Errorf("daily = %q, want sunrise/sunset", u.Query().Get("daily"))
+	}
+}
+
+func TestDetailLinesDualLang(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	opts := testOptions()
+	opts.DualLang = true
+	r := testReport()
+	r.Weather.Humidity, r.Weather.UVIndex = ptr(55.0), ptr(3.0)
+	r.Weather.TodayMin, r.Weather.TodayMax = ptr(4.0), ptr(15.0)
+
+	got := captureStdout(t, func() {
+		printOneLine(r, opts)
+		printExtraWeather(r.Weather, opts)
+	})
+	want := "서울 | ☀️  맑음 (Clear) 12.3°C | 대기질 (air quality) 보통 (moderate)\n" +
+		"습도 (humidity) 55% | 자외선 (UV) 3.0 | 오늘 (today) 4.0°C ~ 15.0°C\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	opts.DualLang = false
+	if got := captureStdout(t, func() { printExtraWeather(r.Weather, opts) }); got != "습도 55% | 자외선 3.0 | 오늘 4.0°C ~ 15.0°C\n" {
+		t.Errorf("ko extra line = %q", got)
+	}
+}
+
+// --detail-level 0은 한 줄, 1은 기본, 2는 습도/자외선, 3은 가스·일출/일몰·달까지 더한다.
+func TestPrintSummaryDetailLevels(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	r := testReport()
+	r.Weather.Humidity = ptr(63.0)
+	r.Weather.UVIndex = ptr(4.2)
+	r.Air.Ozone, r.Air.NO2 = ptr(61.0), ptr(20.0)
+
+	const base = `서울 | 03-14 15:30 (KST)
+☀️  맑음  12.3°C (체감 11.0°C)  |  강수 20%
+가시거리 24.0km (좋음)
+바람 9.4km/h
+`
+	const air = `대기질 보통 🙂 (AQI 72)
+미세먼지(PM10) 보통 | 초미세먼지(PM2.5) 보통
+`
+	const extra = "습도 63% | 자외선 4.2\n"
+	cases := []struct {
+		level int
+		want  string
+	}{
+		{detailOneLine, "서울 | ☀️  맑음 12.3°C | 대기질 보통\n"},
+		{detailDefault, base + air},
+		{detailExtra, base + extra + air},
+		{detailAll, base + extra + air + `오존(O₃) 61㎍/m³ 보통
+이산화질소(NO₂) 20㎍/m³ 좋음
+일출 06:45 | 일몰 18:35
+달 위상 🌕 보름 (100%)
+`},
+	}
+	for _, c := range cases {
+		opts := testOptions()
+		opts.DetailLevel = c.level
+		if got := captureStdout(t, func() { printSummary(r, opts) }); got != c.want {
+			t.Errorf("level %d:\n--- got\n%s--- want\n%s", c.level, got, c.want)
+		}
+	}
+
+	// 가스는 레벨 3에서만 요청한다. (--dry-run 의 대기질 URL)
+	for level := detailOneLine; level <= detailAll; level++ {
+		opts := testOptions()
+		opts.DetailLevel = level
+		out := captureStdout(t, func() { printDryRun(r.Location, opts) })
+		if got, want := strings.Contains(out, "ozone"), level == detailAll; got != want {
+			t.Errorf("level %d: gases requested = %v, want %v\n%s", level, got, want, out)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ---------- now --diff ----------
+// diffSide는 --diff 한쪽 도시의 조회 결과다.
+type diffSide struct {
+	Report Report
+	Err    error
+}
+
+// RunDiff는 두 도시의 기온/체감/대기질 차이를 한 줄로 출력한다.
+// 두 도시의 단위가 달라질 수 있어 --unit=auto 는 섭씨로 본다.
+func RunDiff(ctx context.Context, cityA, cityB string, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	if opts.Unit == unitAuto {
+		opts.Unit = "c"
+	}
+	opts.BestEffort = true // 대기질만 실패하면 기온 차이는 보여준다.
+	opts.DryRun = false
+
+	sides := fetchPair(cityA, cityB, func(city string) diffSide {
+		r, _, err := fetchReport(ctx, client, city, opts)
+		return diffSide{Report: r, Err: err}
+	})
+	for _, s := range sides {
+		if s.Err != nil {
+			return opts.deadlineErr(ctx, s.Err)
+		}
+	}
+	a, b := sides[0].Report, sides[1].Report
+	if a.WeatherErr != nil || b.WeatherErr != nil {
+		return opts.deadlineErr(ctx, errors.Join(a.WeatherErr, b.WeatherErr))
+	}
+
+	fmt.Println(diffVerdict(a, b, opts))
+	return nil
+}
+
+// diffVerdict는 "서울이 제주보다 5.2°C 더 춥고 (체감 6.0°C 차), 공기는 비슷함" 같은 문장이다.
+// 차이는 출력 자릿수로 반올림해 0이면 같다고 본다.
+func diffVerdict(a, b Report, opts Options) string {
+	p, unit := opts.Precision, tempSymbol(opts)
+	nameA, nameB := a.Location.Name, b.Location.Name
+
+	var temp string
+	switch d := a.Weather.Temperature2m - b.Weather.Temperature2m; {
+	case sameAtPrecision(p, d):
+		temp = fmt.Sprintf("%s, %s 기온이 같고", nameA, nameB)
+	case d < 0:
+		temp = fmt.Sprintf("%s%s %s보다 %s%s 더 춥고", nameA, subjectParticle(nameA), nameB, p.format("temp", -d), unit)
+	default:
+		temp = fmt.Sprintf("%s%s %s보다 %s%s 더 따뜻하고", nameA, subjectParticle(nameA), nameB, p.format("temp", d), unit)
+	}
+	if feels := a.Weather.ApparentTemperature - b.Weather.ApparentTemperature; !sameAtPrecision(p, feels) {
+		temp += fmt.Sprintf(" (체감 %s%s 차)", p.format("temp", math.Abs(feels)), unit)
+	}
+
+	return temp + ", " + airComparison(a, b)
+}
+
+func sameAtPrecision(p Precision, d float64) bool {
+	return p.format("temp", math.Abs(d)) == p.format("temp", 0)
+}
+
+// airComparison은 a 기준 공기 비교다. 등급이 같으면 비슷함이다.
+func airComparison(a, b Report) string {
+	if a.AirErr != nil || b.AirErr != nil {
+		return "공기는 비교할 수 없음 (대기질 정보 없음)"
+	}
+	aqiA, aqiB := aqiOf(a.Air), aqiOf(b.Air)
+	switch {
+	case aqiLabel(aqiA) == aqiLabel(aqiB):
+		return fmt.Sprintf("공기는 비슷함 (AQI %d vs %d)", aqiA, aqiB)
+	case aqiA < aqiB:
+		return fmt.Sprintf("공기는 더 좋음 (AQI %d vs %d)", aqiA, aqiB)
+	default:
+		return fmt.Sprintf("공기는 더 나쁨 (AQI %d vs %d)", aqiA, aqiB)
+	}
+}
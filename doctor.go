@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// ---------- doctor 명령 ----------
+// RunDoctor는 실제로 적용되는 설정, 캐시, 터미널 정보와 API 연결 상태를 한 번에 출력한다.
+// 문제가 하나라도 있으면 false를 돌려준다.
+func RunDoctor(ctx context.Context, opts Options) bool {
+	healthy := true
+	check := func(ok bool, format string, args ...any) {
+		mark := "ok  "
+		if !ok {
+			mark = "FAIL"
+			healthy = false
+		}
+		fmt.Printf("  [%s] %s\n", mark, fmt.Sprintf(format, args...))
+	}
+
+	fmt.Println("설정")
+	cfg := Config{}
+	if path, _, err := configPath(opts.ConfigFile); err != nil {
+		check(false, "설정 파일 경로: %v", err)
+	} else if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Printf("         설정 파일 %s (없음, weather init 으로 만들기)\n", path)
+	} else if c, err := loadConfig(path); err != nil {
+		check(false, "설정 파일: %v", err)
+	} else {
+		cfg = c
+		check(true, "설정 파일 %s", path)
+	}
+	fmt.Printf("         기본 도시 %s | 프로필 %d개\n", orDefault(defaultCity("", cfg), "(없음)"), len(cfg.Profiles))
+	fmt.Printf("         언어 %s | 단위 %s | 아이콘 %s | 색 %v\n", opts.Lang, opts.Unit, opts.IconSet, opts.UseColor)
+
+	fmt.Println("캐시")
+	if opts.NoCache {
+		fmt.Println("         사용 안 함 (--no-cache)")
+	} else if dir, err := cacheDir(opts.CacheDir); err != nil {
+		check(false, "캐시 경로: %v", err)
+	} else {
+		entries, size, _ := diskCache{dir: dir}.stats()
+		err := checkWritable(dir)
+		check(err == nil, "%s (%d개, %s)%s", dir, entries, humanBytes(size), errSuffix(err))
+	}
+
+	fmt.Println("터미널")
+	fmt.Printf("         stdout 터미널 %v | TERM=%s | COLUMNS=%s | 차트 폭 %d\n",
+		isTerminal(os.Stdout), os.Getenv("TERM"), os.Getenv("COLUMNS"), terminalWidth())
+	for _, k := range []string{"HTTPS_PROXY", "HTTP_PROXY", "NO_PROXY", "NO_COLOR"} {
+		if v := os.Getenv(k); v != "" {
+			fmt.Printf("         %s=%s\n", k, redactURL(v))
+		}
+	}
+	fmt.Println("         API 키: 사용하지 않음 (Open-Meteo 무료 API)")
+
+	fmt.Println("연결")
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		check(false, "HTTP 클라이언트: %v", err)
+		return false
+	}
+	q := queryOpts{Current: []string{"temperature_2m"}}
+	aq := queryOpts{Current: []string{"pm10"}}
+	for _, c := range []struct {
+		name string
+		urls []string
+	}{
+		{"지오코딩", mirrorURLs(endpoints.Geocode, func(b string) string { return buildGeocodeURL(b, "Seoul", "en", 1) })},
+		{"날씨", mirrorURLs(endpoints.Forecast, func(b string) string { return buildForecastURL(b, 37.57, 126.98, q) })},
+		{"대기질", mirrorURLs(endpoints.Air, func(b string) string { return buildAirURL(b, 37.57, 126.98, aq) })},
+	} {
+		host, elapsed, err := pingEndpoint(ctx, client, c.urls, opts.timeoutOr(0))
+		if err != nil {
+			check(false, "%s %s: %v", c.name, host, err)
+			continue
+		}
+		check(true, "%s %s (%s)", c.name, host, elapsed.Round(time.Millisecond))
+	}
+	return healthy
+}
+
+// pingEndpoint는 요청 하나를 보내 200인지와 걸린 시간을 본다.
+func pingEndpoint(ctx context.Context, client *http.Client, urls []string, timeout time.Duration) (string, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	host := urls[0]
+	if u, err := url.Parse(urls[0]); err == nil {
+		host = u.Host
+	}
+	start := time.Now()
+	resp, err := fetchWithFallback(ctx, client, urls)
+	if err != nil {
+		return host, 0, err
+	}
+	defer resp.Body.Close()
+	host = resp.Request.URL.Host
+	if resp.StatusCode != http.StatusOK {
+		return host, 0, fmt.Errorf("bad status: %s", resp.Status)
+	}
+	return host, time.Since(start), nil
+}
+
+// checkWritable은 dir에 파일을 만들 수 있는지 본다. (없으면 만든다)
+func checkWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".doctor-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
+
+// redactURL은 프록시 URL 등의 사용자 정보(아이디, 비밀번호, 토큰)를 통째로 가린다.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = url.User("xxxxx")
+	return u.String()
+}
+
+func errSuffix(err error) string {
+	if err == nil {
+		return ""
+	}
+	return ": " + err.Error()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// blockingForecast는 날씨 요청을 끝까지 붙잡아 두고, 요청 ctx가 취소되면 cancelled로 알린다.
+func blockingForecast(t *testing.T, srv *httptest.Server) <-chan struct{} {
+	t.Helper()
+	cancelled := make(chan struct{})
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	next := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/forecast" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-release:
+		}
+	})
+	return cancelled
+}
+
+var seoul = GeoResult{ID: 1, Name: "서울", Latitude: 37.566, Longitude: 126.9784}
+
+// 대기질이 바로 실패하면 기다리지 않고 날씨 요청도 취소한다.
+func TestFetchAllCancelsSiblingOnError(t *testing.T) {
+	srv := fakeOpenMeteo(t, nil)
+	cancelled := blockingForecast(t, srv)
+	mux := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/air-quality" {
+			http.Error(w, `{"error":true,"reason":"bad"}`, http.StatusBadRequest)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+	opts := testOptions()
+	opts.Timeout = 5 * time.Second
+
+	start := time.Now()
+	_, err := fetchAll(context.Background(), srv.Client(), seoul, opts)
+	if err == nil {
+		t.Fatal("expected air error")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Endpoint != "air quality" {
+		t.Errorf("err = %v, want the air APIError", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %s, want prompt return", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("forecast request was not cancelled")
+	}
+}
+
+// 부모 ctx가 취소되면 (Ctrl-C) 막힌 요청을 기다리지 않고 바로 돌아온다.
+// --best-effort 면 대기질은 살리고 날씨 에러만 남긴다.
+func TestFetchAllReturnsOnCancel(t *testing.T) {
+	for _, bestEffort := range []bool{false, true} {
+		srv := fakeOpenMeteo(t, nil)
+		cancelled := blockingForecast(t, srv)
+		opts := testOptions()
+		opts.Timeout = 5 * time.Second
+		opts.BestEffort = bestEffort
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		res, err := fetchAll(ctx, srv.Client(), seoul, opts)
+		if bestEffort && err == nil {
+			err = res.WeatherErr
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("best-effort=%v: took %s, want prompt return", bestEffort, elapsed)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("best-effort=%v: err = %v, want context.Canceled", bestEffort, err)
+		}
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Errorf("best-effort=%v: forecast request was not cancelled", bestEffort)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ---------- Number formatting ----------
+// 필드별 소수점 자릿수. --fmt temp=1 --fmt pm10=0 처럼 덮어쓴다.
+var defaultPrecision = map[string]int{
+	"temp":   1,
+	"precip": 0,
+	"rain":   1, // mm/h (--unit=f 면 in/h, 한 자리 더)
+	"pm10":   1,
+	"pm25":   1,
+
+	"visibility": 1,
+	"wind":       1,
+	"humidity":   0,
+	"uv":         1,
+
+	// air --detail 가스
+	"o3":  0,
+	"no2": 0,
+	"so2": 0,
+	"co":  0,
+}
+
+// Precision은 필드 → 소수점 자릿수 매핑이며 flag.Value를 구현한다.
+type Precision map[string]int
+
+func newPrecision() Precision {
+	p := Precision{}
+	for k, v := range defaultPrecision {
+		p[k] = v
+	}
+	return p
+}
+
+func (p Precision) String() string {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%d", k, p[k]))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (p Precision) Set(s string) error {
+	field, val, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("expected field=decimals, got %q", s)
+	}
+
+	field = strings.ToLower(strings.TrimSpace(field))
+	if _, known := defaultPrecision[field]; !known {
+		return fmt.Errorf("unknown field %q (valid: %s)", field, precisionFields())
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil || n < 0 || n > 6 {
+		return fmt.Errorf("invalid decimals for %s: %q (0~6)", field, val)
+	}
+
+	p[field] = n
+	return nil
+}
+
+func (p Precision) decimals(field string) int {
+	if n, ok := p[field]; ok {
+		return n
+	}
+	return defaultPrecision[field]
+}
+
+// format은 field의 자릿수로 v를 문자열로 만든다.
+func (p Precision) format(field string, v float64) string {
+	return strconv.FormatFloat(v, 'f', p.decimals(field), 64)
+}
+
+// floor는 반올림 대신 field 자릿수에서 내림한다. (99.6 → "99")
+func (p Precision) floor(field string, v float64) string {
+	scale := math.Pow(10, float64(p.decimals(field)))
+	return strconv.FormatFloat(math.Floor(v*scale+1e-9)/scale, 'f', p.decimals(field), 64)
+}
+
+func precisionFields() string {
+	keys := make([]string, 0, len(defaultPrecision))
+	for k := range defaultPrecision {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ", ")
+}
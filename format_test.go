@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrecisionSet(t *testing.T) {
+	cases := []struct {
+		in      string
+		field   string
+		want    int
+		wantErr string // 에러 문구 일부, 비어 있으면 성공
+	}{
+		{"temp=0", "temp", 0, ""},
+		{"pm10=3", "pm10", 3, ""},
+		{" PM25 = 2 ", "pm25", 2, ""},
+		{"uv=6", "uv", 6, ""},
+		{"temp", "", 0, "expected field=decimals"},
+		{"pressure=0", "", 0, `unknown field "pressure"`},
+		{"temp=7", "", 0, "invalid decimals for temp"},
+		{"temp=-1", "", 0, "invalid decimals for temp"},
+		{"temp=one", "", 0, "invalid decimals for temp"},
+		{"temp=", "", 0, "invalid decimals for temp"},
+	}
+	for _, c := range cases {
+		p := newPrecision()
+		err := p.Set(c.in)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("Set(%q) err = %v, want %q", c.in, err, c.wantErr)
+			}
+			if p.String() != newPrecision().String() {
+				t.Errorf("Set(%q) changed precision on error: %s", c.in, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Set(%q): %v", c.in, err)
+			continue
+		}
+		if got := p.decimals(c.field); got != c.want {
+			t.Errorf("Set(%q): %s = %d, want %d", c.in, c.field, got, c.want)
+		}
+	}
+}
+
+// --fmt 는 여러 번 줄 수 있고, 뒤에 준 값이 이긴다. 주지 않은 필드는 기본값이다.
+func TestPrecisionFlagRepeated(t *testing.T) {
+	var opts Options
+	fs := newFlagSet("weather", &opts)
+	if err := fs.Parse([]string{"--fmt", "temp=0", "--fmt", "pm10=0", "--fmt", "temp=2"}); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		field string
+		v     float64
+		want  string
+	}{
+		{"temp", 12.345, "12.35"},
+		{"pm10", 45.6, "46"},
+		{"pm25", 22.04, "22.0"},
+		{"humidity", 63.4, "63"},
+	}
+	for _, c := range cases {
+		if got := opts.Precision.format(c.field, c.v); got != c.want {
+			t.Errorf("format(%s, %v) = %s, want %s", c.field, c.v, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+)
+
+// ---------- Friendly errors ----------
+// friendlyError는 흔한 에러 체인을 짧은 안내 문구로 바꾼다. 모르는 에러는 그대로 돌려준다.
+// 전체 체인은 --debug-errors (또는 $WEATHER_DEBUG) 로 본다.
+func friendlyError(err error) string {
+	var (
+		apiErr  *APIError
+		dnsErr  *net.DNSError
+		opErr   *net.OpError
+		netErr  net.Error
+		certErr *x509.UnknownAuthorityError
+		hostErr x509.HostnameError
+	)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "응답 시간이 초과되었습니다 (--timeout, --deadline 으로 늘릴 수 있습니다)"
+	case errors.As(err, &dnsErr):
+		return "서버 주소를 찾을 수 없습니다 (DNS, 네트워크 연결 확인)"
+	case errors.As(err, &certErr), errors.As(err, &hostErr):
+		return "서버 인증서를 확인할 수 없습니다"
+	case errors.As(err, &opErr):
+		return "네트워크에 연결할 수 없습니다"
+	case errors.As(err, &apiErr):
+		return friendlyAPIError(apiErr)
+	}
+	return err.Error()
+}
+
+func friendlyAPIError(e *APIError) string {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return "요청 한도 초과, 잠시 후 다시 시도하세요"
+	case e.StatusCode >= 500:
+		return fmt.Sprintf("%s 서버 오류입니다 (%d), 잠시 후 다시 시도하세요", e.Endpoint, e.StatusCode)
+	case e.StatusCode != 0:
+		msg := fmt.Sprintf("%s 요청이 거부되었습니다 (%d)", e.Endpoint, e.StatusCode)
+		var reason apiReason
+		if errors.As(e.Err, &reason) {
+			msg += ": " + string(reason)
+		}
+		return msg
+	case errors.Is(e.Err, ErrNoCurrentData):
+		return e.Endpoint + " 응답에 현재 데이터가 없습니다 (서버 점검 중일 수 있습니다)"
+	case e.Reason == "decode failed":
+		return e.Endpoint + " 응답 형식이 올바르지 않습니다"
+	}
+	return e.Error()
+}
+
+// debugErrorsEnabled는 --debug-errors 이거나, --concise-errors 없이 $WEATHER_DEBUG 가 있을 때다.
+func (o Options) debugErrorsEnabled() bool {
+	if o.DebugErrors {
+		return true
+	}
+	return !o.ConciseErrors && os.Getenv("WEATHER_DEBUG") != ""
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ---------- Geocoder ----------
+// Geocoder는 도시 이름을 좌표로 바꾼다. --geocode-provider 로 고른다.
+//
+//	openmeteo  Open-Meteo 지오코딩 API (기본, 디스크 캐시 사용)
+//	local      --geocode-db 의 CSV 파일 (오프라인)
+//
+// Options.Geocoder 를 넣으면 provider와 상관없이 그것을 쓴다.
+type Geocoder interface {
+	Geocode(ctx context.Context, city string) (GeoResult, error)
+}
+
+const (
+	geocodeProviderOpenMeteo = "openmeteo"
+	geocodeProviderLocal     = "local"
+)
+
+func newGeocoder(client *http.Client, opts Options) (Geocoder, error) {
+	if opts.Geocoder != nil {
+		return opts.Geocoder, nil
+	}
+	switch opts.GeocodeProvider {
+	case "", geocodeProviderOpenMeteo:
+		return openMeteoGeocoder{client: client, lang: opts.lang(), count: opts.geocodeCount(), cache: opts.cache(), refresh: opts.RefreshGeocode}, nil
+	case geocodeProviderLocal:
+		return loadLocalGeocoder(opts.GeocodeDB)
+	default:
+		return nil, fmt.Errorf("unknown geocode provider %q (openmeteo, local)", opts.GeocodeProvider)
+	}
+}
+
+// geocodeCount는 --geocode-count 이고, 없으면 defaultGeocodeCount다.
+func (o Options) geocodeCount() int {
+	if o.GeocodeCount <= 0 {
+		return defaultGeocodeCount
+	}
+	return o.GeocodeCount
+}
+
+// ---------- Open-Meteo ----------
+type openMeteoGeocoder struct {
+	client *http.Client
+	lang   string
+	count  int
+	cache  diskCache
+
+	// refresh면 캐시를 읽지 않는다. (--refresh-geocode, 쓰기는 그대로)
+	refresh bool
+}
+
+func (g openMeteoGeocoder) Geocode(ctx context.Context, city string) (GeoResult, error) {
+	key := g.lang + "|" + strings.ToLower(strings.TrimSpace(city))
+	if g.count != defaultGeocodeCount {
+		key += "|" + strconv.Itoa(g.count) // 기본값이면 도시 이름만으로 키를 만든다.
+	}
+
+	var loc GeoResult
+	if !g.refresh && g.cache.get("geocode", key, geocodeCacheTTL, &loc) {
+		return loc, nil
+	}
+
+	loc, err := geocode(ctx, g.client, city, g.lang, g.count)
+	if err != nil {
+		return GeoResult{}, err
+	}
+	if err := g.cache.put("geocode", key, loc); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return loc, nil
+}
+
+// ---------- Local CSV ----------
+// 첫 줄은 헤더다. name, latitude, longitude 는 필수이고
+// country, country_code, admin1, population 은 있으면 쓴다. 열 순서는 자유다.
+//
+//	name,country,country_code,latitude,longitude,population
+//	서울,대한민국,KR,37.5665,126.9780,9668465
+type localGeocoder struct {
+	places []GeoResult
+}
+
+func loadLocalGeocoder(path string) (localGeocoder, error) {
+	if path == "" {
+		return localGeocoder{}, errors.New("--geocode-provider=local requires --geocode-db")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return localGeocoder{}, fmt.Errorf("geocode db open failed: %w", err)
+	}
+	defer f.Close()
+
+	places, err := readPlacesCSV(f)
+	if err != nil {
+		return localGeocoder{}, fmt.Errorf("geocode db %s: %w", path, err)
+	}
+	return localGeocoder{places: places}, nil
+}
+
+func readPlacesCSV(r io.Reader) ([]GeoResult, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header read failed: %w", err)
+	}
+	col := map[string]int{}
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, req := range []string{"name", "latitude", "longitude"} {
+		if _, ok := col[req]; !ok {
+			return nil, fmt.Errorf("missing column %q", req)
+		}
+	}
+
+	field := func(rec []string, name string) string {
+		if i, ok := col[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var places []GeoResult
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		lat, err1 := strconv.ParseFloat(field(rec, "latitude"), 64)
+		lon, err2 := strconv.ParseFloat(field(rec, "longitude"), 64)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("line %d: invalid coordinates", line)
+		}
+		pop, _ := strconv.Atoi(field(rec, "population"))
+
+		p := GeoResult{
+			Name:        field(rec, "name"),
+			Country:     field(rec, "country"),
+			CountryCode: strings.ToUpper(field(rec, "country_code")),
+			Admin1:      field(rec, "admin1"),
+			Population:  pop,
+			Latitude:    lat,
+			Longitude:   lon,
+		}
+		if !validGeoResult(p) {
+			return nil, fmt.Errorf("line %d: invalid place %q", line, p.Name)
+		}
+		places = append(places, p)
+	}
+	return places, nil
+}
+
+// Geocode는 이름이 같은 (대소문자 무시) 곳 중 인구가 가장 많은 곳을 고른다.
+func (g localGeocoder) Geocode(_ context.Context, city string) (GeoResult, error) {
+	city, err := normalizeCity(city)
+	if err != nil {
+		return GeoResult{}, err
+	}
+
+	var matches []GeoResult
+	for _, p := range g.places {
+		if strings.EqualFold(p.Name, city) {
+			matches = append(matches, p)
+		}
+	}
+	if len(matches) == 0 {
+		return GeoResult{}, fmt.Errorf("no results for city: %q (local geocode db)", city)
+	}
+	rankByPopulation(matches)
+	return matches[0], nil
+}
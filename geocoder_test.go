@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildGeocodeURL(t *testing.T) {
+	cases := []struct {
+		city, lang string
+		count      int
+		want       string
+	}{
+		{"seoul", "ko", 1, geocodeBaseURL + "?count=1&format=json&language=ko&name=seoul"},
+		{"new york", "en", 5, geocodeBaseURL + "?count=5&format=json&language=en&name=new+york"},
+		{"서울", "ko", 100, geocodeBaseURL + "?count=100&format=json&language=ko&name=%EC%84%9C%EC%9A%B8"},
+	}
+	for _, c := range cases {
+		if got := buildGeocodeURL(geocodeBaseURL, c.city, c.lang, c.count); got != c.want {
+			t.Errorf("buildGeocodeURL(%q, %q, %d) = %s, want %s", c.city, c.lang, c.count, got, c.want)
+		}
+	}
+}
+
+// 기본 (plain now) 경로는 후보를 하나만 요청하고, --geocode-count 를 주면 그 수만큼 요청한다.
+func TestGeocodeCountInRequest(t *testing.T) {
+	var counts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counts = append(counts, r.URL.Query().Get("count"))
+		json.NewEncoder(w).Encode(GeoResponse{Results: []GeoResult{
+			{ID: 1, Name: "서울", Country: "대한민국", Latitude: 37.566, Longitude: 126.9784},
+		}})
+	}))
+	defer srv.Close()
+	orig := endpoints
+	endpoints.Geocode = []string{srv.URL}
+	defer func() { endpoints = orig }()
+
+	for _, n := range []int{0, 1, 7} {
+		opts := testOptions()
+		opts.GeocodeCount = n
+		g, err := newGeocoder(srv.Client(), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := g.Geocode(context.Background(), "서울"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := []string{"1", "1", "7"}
+	if len(counts) != len(want) {
+		t.Fatalf("requests = %v, want %v", counts, want)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("request %d count=%s, want %s", i, counts[i], want[i])
+		}
+	}
+}
+
+// fakeGeocoder는 places에 있는 이름만 찾는 Geocoder다. 받은 도시를 calls에 남긴다.
+type fakeGeocoder struct {
+	places map[string]GeoResult
+	calls  []string
+}
+
+func (g *fakeGeocoder) Geocode(ctx context.Context, city string) (GeoResult, error) {
+	g.calls = append(g.calls, city)
+	if r, ok := g.places[city]; ok {
+		return r, nil
+	}
+	return GeoResult{}, fmt.Errorf("no results for city: %q", city)
+}
+
+// RunNow는 Options.Geocoder 로 넣은 Geocoder만 쓰고 지오코딩 API는 부르지 않는다.
+func TestRunNowWithFakeGeocoder(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	srv := fakeOpenMeteo(t, nil)
+	var geocodeHits int
+	mux := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/search" {
+			geocodeHits++
+		}
+		mux.ServeHTTP(w, r)
+	})
+
+	fake := &fakeGeocoder{places: map[string]GeoResult{
+		"home": {ID: 1, Name: "우리 집", Country: "대한민국", CountryCode: "KR", Latitude: 37.5, Longitude: 127.0},
+	}}
+	cases := []struct {
+		city    string
+		header  string // 첫 줄 앞부분, 비어 있으면 에러를 기대한다.
+		wantErr string
+	}{
+		{"home", "우리 집 | 03-14 15:30", ""},
+		{"office", "", `no results for city: "office"`},
+	}
+	for _, c := range cases {
+		opts := testOptions()
+		opts.Geocoder = fake
+		var err error
+		out := captureStdout(t, func() { err = RunNow(context.Background(), c.city, opts) })
+		if c.wantErr != "" {
+			if err == nil || err.Error() != c.wantErr {
+				t.Errorf("%s: err = %v, want %s", c.city, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.city, err)
+		}
+		if !strings.HasPrefix(out, c.header) {
+			t.Errorf("%s: output starts %q, want %q", c.city, strings.SplitN(out, "\n", 2)[0], c.header)
+		}
+	}
+	if want := []string{"home", "office"}; strings.Join(fake.calls, ",") != strings.Join(want, ",") {
+		t.Errorf("geocoder calls = %v, want %v", fake.calls, want)
+	}
+	if geocodeHits != 0 {
+		t.Errorf("geocoding API hit %d times, want 0", geocodeHits)
+	}
+}
+
+func TestValidGeoResult(t *testing.T) {
+	cases := []struct {
+		name string
+		r    GeoResult
+		want bool
+	}{
+		{"seoul", GeoResult{Name: "서울", Latitude: 37.566, Longitude: 126.9784}, true},
+		{"equator", GeoResult{Name: "Quito", Latitude: 0, Longitude: -78.5}, true},
+		{"meridian", GeoResult{Name: "Greenwich", Latitude: 51.48, Longitude: 0}, true},
+		{"poles", GeoResult{Name: "South Pole", Latitude: -90, Longitude: 180}, true},
+		{"missing coords", GeoResult{Name: "Nowhere"}, false},
+		{"blank name", GeoResult{Name: "  ", Latitude: 37.566, Longitude: 126.9784}, false},
+		{"lat out of range", GeoResult{Name: "x", Latitude: 91, Longitude: 10}, false},
+		{"lon out of range", GeoResult{Name: "x", Latitude: 10, Longitude: -180.5}, false},
+	}
+	for _, c := range cases {
+		if got := validGeoResult(c.r); got != c.want {
+			t.Errorf("%s: validGeoResult = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// 첫 결과가 (0,0) 이면 건너뛰고 다음 결과를 쓴다. 쓸 만한 결과가 없으면 에러다.
+func TestGeocodeSkipsDegenerate(t *testing.T) {
+	degenerate := GeoResult{ID: 1, Name: "Smallville", Country: "United States", Latitude: 0, Longitude: 0}
+	good := GeoResult{ID: 2, Name: "Smallville", Country: "United States", Latitude: 39.18, Longitude: -96.57}
+
+	cases := []struct {
+		name    string
+		results []GeoResult
+		wantID  int // 0이면 에러를 기대한다.
+	}{
+		{"degenerate then valid", []GeoResult{degenerate, good}, 2},
+		{"only degenerate", []GeoResult{degenerate}, 0},
+		{"no name", []GeoResult{{ID: 3, Latitude: 39.18, Longitude: -96.57}}, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			fakeSearch(t, c.results)
+			loc, err := geocode(context.Background(), http.DefaultClient, "Smallville", "en", len(c.results))
+			if c.wantID == 0 {
+				if err == nil {
+					t.Errorf("got %+v, want error", loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.ID != c.wantID {
+				t.Errorf("picked ID %d, want %d", loc.ID, c.wantID)
+			}
+		})
+	}
+}
+
+// language=ko 결과 이름이 비어 있거나 검색어를 그대로 옮긴 것이면 language=en 으로 다시 찾아 그 이름을 쓴다.
+func TestGeocodeEnglishFallback(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		ko, en   string // 같은 ID의 ko/en 결과 이름
+		want     string // 비어 있으면 에러를 기대한다.
+		wantQ    string
+		enLookup bool
+	}{
+		{"ko empty", "Llanfairpwllgwyngyll", "", "Llanfairpwllgwyngyll", "Llanfairpwllgwyngyll", "Llanfairpwllgwyngyll", true},
+		{"ko transliteration", "zermatt", "zermatt", "Zermatt", "Zermatt", "zermatt", true},
+		{"ko proper name", "tokyo", "도쿄", "Tokyo", "도쿄", "", false},
+		{"both empty", "nowhere", "", "", "", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var enLookups int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				name := c.ko
+				if r.URL.Query().Get("language") == "en" {
+					enLookups++
+					name = c.en
+				}
+				json.NewEncoder(w).Encode(GeoResponse{Results: []GeoResult{
+					{ID: 7, Name: name, Latitude: 53.22, Longitude: -4.2},
+				}})
+			}))
+			defer srv.Close()
+			orig := endpoints
+			endpoints.Geocode = []string{srv.URL}
+			defer func() { endpoints = orig }()
+
+			loc, err := geocode(context.Background(), srv.Client(), c.query, "ko", 1)
+			if (enLookups > 0) != c.enLookup {
+				t.Errorf("en lookups = %d, want lookup %v", enLookups, c.enLookup)
+			}
+			if c.want == "" {
+				if err == nil {
+					t.Errorf("got %+v, want error", loc)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.Name != c.want || loc.Query != c.wantQ {
+				t.Errorf("got name %q query %q, want %q %q", loc.Name, loc.Query, c.want, c.wantQ)
+			}
+		})
+	}
+}
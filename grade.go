@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ---------- Grade thresholds ----------
+// 각 값은 해당 등급의 상한(이하)이다. 마지막 상한을 넘으면 가장 나쁜 등급이 된다.
+type Thresholds struct {
+	PM10 []float64 `json:"pm10"`   // 좋음/보통/나쁨 (3개)
+	PM25 []float64 `json:"pm2_5"`  // 좋음/보통/나쁨 (3개)
+	AQI  []float64 `json:"us_aqi"` // 좋음/보통/나쁨/매우 나쁨 (4개)
+
+	// ConditionLabels는 날씨 라벨 덮어쓰기다. 키는 WMO 코드, 밤만 바꾸려면 "0n" 처럼 n을 붙인다.
+	// 예: {"0": "쾌청", "0n": "별 보이는 밤", "61": "비 조금"}
+	ConditionLabels map[string]string `json:"condition_labels,omitempty"`
+}
+
+// 국내에서 흔히 쓰는 공공 기준 (PM ㎍/m³) 과 US AQI 구간
+var defaultThresholds = Thresholds{
+	PM10: []float64{30, 80, 150},
+	PM25: []float64{15, 35, 75},
+	AQI:  []float64{50, 100, 150, 200},
+}
+
+// thresholds는 --threshold-file 로 바뀔 수 있다. (시작 시 한 번만 설정)
+var thresholds = defaultThresholds
+
+var (
+	gradeNamesKR = []string{"좋음", "보통", "나쁨", "매우 나쁨"}
+	aqiNamesKR   = []string{"좋음", "보통", "나쁨", "매우 나쁨", "위험"}
+	aqiEmoji     = []string{"😊", "🙂", "😷", "🤢", "☠️"}
+	aqiNamesEN   = []string{"good", "moderate", "unhealthy for sensitive groups", "unhealthy", "hazardous"}
+)
+
+// gradeIndex는 v가 들어가는 구간 번호(0부터)를 돌려준다.
+func gradeIndex(v float64, cutoffs []float64) int {
+	for i, c := range cutoffs {
+		if v <= c {
+			return i
+		}
+	}
+	return len(cutoffs)
+}
+
+// gradeRange는 구간 번호와 그 구간의 경계 (lo, hi]를 돌려준다.
+// 첫 구간의 lo는 0, 마지막 구간의 hi는 +Inf다.
+func gradeRange(v float64, cutoffs []float64) (idx int, lo, hi float64) {
+	idx = gradeIndex(v, cutoffs)
+	if idx > 0 {
+		lo = cutoffs[idx-1]
+	}
+	hi = math.Inf(1)
+	if idx < len(cutoffs) {
+		hi = cutoffs[idx]
+	}
+	return idx, lo, hi
+}
+
+// PM10 (미세먼지) ㎍/m³
+func pm10GradeKR(pm10 float64) (grade string, lo, hi float64) {
+	i, lo, hi := gradeRange(pm10, thresholds.PM10)
+	return gradeNamesKR[i], lo, hi
+}
+
+// pm25AvgHours는 --pm25-24h 평균 구간이다.
+// 자료가 이 중 pm25AvgMinHours 시간 미만이면 "자료 부족"으로 표시한다. (75% 기준)
+const (
+	pm25AvgHours    = 24
+	pm25AvgMinHours = 18
+)
+
+// PM2.5 (초미세먼지) ㎍/m³
+func pm25GradeKR(pm25 float64) (grade string, lo, hi float64) {
+	i, lo, hi := gradeRange(pm25, thresholds.PM25)
+	return gradeNamesKR[i], lo, hi
+}
+
+// precipIntensityKR은 시간당 강수량(mm/h)의 강도다. (기상청 기준: 3 미만 약한비, 15 미만 보통비, 30 미만 강한비)
+func precipIntensityKR(mmPerHour float64) string {
+	switch {
+	case mmPerHour <= 0:
+		return "없음"
+	case mmPerHour < 3:
+		return "약한비"
+	case mmPerHour < 15:
+		return "보통비"
+	case mmPerHour < 30:
+		return "강한비"
+	default:
+		return "폭우"
+	}
+}
+
+// precipBandKR은 강수 확률(%) 구간이다.
+func precipBandKR(pct float64) string {
+	switch {
+	case pct < 30:
+		return "낮음"
+	case pct < 60:
+		return "보통"
+	case pct < 80:
+		return "높음"
+	default:
+		return "매우 높음"
+	}
+}
+
+// explainRange는 --explain 용 "81~150 ㎍/m³ 범위, 현재 92" 문구를 만든다. 구간은 (lo, hi] 이다.
+// 값과 경계가 모두 정수면 하한을 lo+1로 줄여 "81~150" 처럼 쓰고,
+// 아니면 (80.5, 12.5 기준 등) 사이 값이 빠지지 않게 "80 초과 ~ 150 이하" 로 쓴다.
+func explainRange(lo, hi, v float64, unit string) string {
+	num := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	isInt := func(f float64) bool { return f == math.Trunc(f) }
+	cur := num(v)
+
+	if isInt(v) && isInt(lo) && (math.IsInf(hi, 1) || isInt(hi)) {
+		from := "0"
+		if lo > 0 {
+			from = num(lo + 1)
+		}
+		if math.IsInf(hi, 1) {
+			return fmt.Sprintf("%s%s 이상, 현재 %s", from, unit, cur)
+		}
+		return fmt.Sprintf("%s~%s%s 범위, 현재 %s", from, num(hi), unit, cur)
+	}
+
+	switch {
+	case math.IsInf(hi, 1):
+		return fmt.Sprintf("%s%s 초과, 현재 %s", num(lo), unit, cur)
+	case lo == 0:
+		return fmt.Sprintf("%s%s 이하, 현재 %s", num(hi), unit, cur)
+	}
+	return fmt.Sprintf("%s 초과 ~ %s%s 이하, 현재 %s", num(lo), num(hi), unit, cur)
+}
+
+func aqiStatus(aqi int) string {
+	i := gradeIndex(float64(aqi), thresholds.AQI)
+	return aqiNamesKR[i] + " " + aqiEmoji[i]
+}
+
+// aqiLabel은 aqiStatus에서 이모지를 뺀 라벨이다.
+func aqiLabel(aqi int) string {
+	return aqiNamesKR[gradeIndex(float64(aqi), thresholds.AQI)]
+}
+
+// loadThresholds는 JSON 파일을 읽어 빠진 항목은 기본값으로 채운다.
+func loadThresholds(path string) (Thresholds, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Thresholds{}, fmt.Errorf("threshold file read failed: %w", err)
+	}
+
+	var t Thresholds
+	if err := json.Unmarshal(b, &t); err != nil {
+		return Thresholds{}, fmt.Errorf("threshold file parse failed (%s): %w", path, err)
+	}
+
+	if t.PM10 == nil {
+		t.PM10 = defaultThresholds.PM10
+	}
+	if t.PM25 == nil {
+		t.PM25 = defaultThresholds.PM25
+	}
+	if t.AQI == nil {
+		t.AQI = defaultThresholds.AQI
+	}
+
+	if err := t.validate(); err != nil {
+		return Thresholds{}, fmt.Errorf("threshold file %s: %w", path, err)
+	}
+	return t, nil
+}
+
+func (t Thresholds) validate() error {
+	checks := []struct {
+		name    string
+		cutoffs []float64
+		want    int
+	}{
+		{"pm10", t.PM10, len(gradeNamesKR) - 1},
+		{"pm2_5", t.PM25, len(gradeNamesKR) - 1},
+		{"us_aqi", t.AQI, len(aqiNamesKR) - 1},
+	}
+
+	for k, v := range t.ConditionLabels {
+		code, ok := strings.CutSuffix(k, "n")
+		n, err := strconv.Atoi(code)
+		if _, known := wmoCodes[n]; err != nil || !known {
+			return fmt.Errorf("condition_labels: %q is not a WMO weather code", k)
+		}
+		if ok && n > 3 {
+			return fmt.Errorf("condition_labels: %q night label is only for codes 0~3", k)
+		}
+		if strings.TrimSpace(v) == "" {
+			return fmt.Errorf("condition_labels: empty label for %q", k)
+		}
+	}
+
+	for _, c := range checks {
+		if len(c.cutoffs) != c.want {
+			return fmt.Errorf("%s: expected %d cutoffs, got %d", c.name, c.want, len(c.cutoffs))
+		}
+		for i, v := range c.cutoffs {
+			if v < 0 {
+				return fmt.Errorf("%s: cutoffs must be non-negative", c.name)
+			}
+			if i > 0 && v <= c.cutoffs[i-1] {
+				return fmt.Errorf("%s: cutoffs must be strictly increasing (%v)", c.name, c.cutoffs)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestExplainRange(t *testing.T) {
+	tests := []struct {
+		name string
+		v    float64
+		cuts []float64
+		unit string
+		want string
+	}{
+		{"first band", 12, defaultThresholds.PM10, " ㎍/m³", "0~30 ㎍/m³ 범위, 현재 12"},
+		{"exact cutoff", 80, defaultThresholds.PM10, " ㎍/m³", "31~80 ㎍/m³ 범위, 현재 80"},
+		{"above cutoff", 81, defaultThresholds.PM10, " ㎍/m³", "81~150 ㎍/m³ 범위, 현재 81"},
+		{"cutoff+0.5", 80.5, defaultThresholds.PM10, " ㎍/m³", "80 초과 ~ 150 ㎍/m³ 이하, 현재 80.5"},
+		{"first band fraction", 12.5, defaultThresholds.PM10, " ㎍/m³", "30 ㎍/m³ 이하, 현재 12.5"},
+		{"top band", 200, defaultThresholds.PM10, " ㎍/m³", "151 ㎍/m³ 이상, 현재 200"},
+		{"top band fraction", 150.5, defaultThresholds.PM10, " ㎍/m³", "150 ㎍/m³ 초과, 현재 150.5"},
+		{"fractional cutoff", 13, []float64{12.5, 35}, "", "12.5 초과 ~ 35 이하, 현재 13"},
+		{"aqi", 92, defaultThresholds.AQI, "", "51~100 범위, 현재 92"},
+	}
+	for _, tt := range tests {
+		_, lo, hi := gradeRange(tt.v, tt.cuts)
+		if got := explainRange(lo, hi, tt.v, tt.unit); got != tt.want {
+			t.Errorf("%s: explainRange(%v) = %q, want %q", tt.name, tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// ---------- Grid cache ----------
+// 가까운 두 위치(예: "seoul", "seoul city hall")는 날씨가 사실상 같다.
+// --grid-cache <deg> 를 주면 좌표를 deg 단위 격자로 반올림해
+// 같은 칸의 forecast/air 응답을 gridCacheTTL 동안 공유한다. (기본 꺼짐)
+const gridCacheTTL = 10 * time.Minute
+
+// gridKey는 좌표를 step 격자로 반올림한 칸 이름과 위치를 뺀 쿼리를 합친다.
+// 단위, 요청 변수 등이 다르면 다른 항목이 된다.
+func gridKey(lat, lon, step float64, q queryOpts) string {
+	v := coordValues(0, 0, q)
+	v.Del("latitude")
+	v.Del("longitude")
+	return fmt.Sprintf("%s,%s|%s", gridCell(lat, step), gridCell(lon, step), v.Encode())
+}
+
+// gridCell은 -0 을 0 으로 맞춰 적도/본초자오선 근처에서도 같은 키가 나오게 한다.
+func gridCell(v, step float64) string {
+	r := math.Round(v/step) * step
+	if r == 0 {
+		r = 0
+	}
+	return fmt.Sprintf("%.4f", r)
+}
+
+// cachedByGrid는 opts.GridCache > 0 이면 격자 캐시를 먼저 보고, 없으면 fetch 결과를 저장한다.
+func cachedByGrid[T any](opts Options, ns string, lat, lon float64, q queryOpts, fetch func() (T, error)) (T, error) {
+	if opts.GridCache <= 0 {
+		return fetch()
+	}
+
+	cache := opts.cache()
+	key := gridKey(lat, lon, opts.GridCache, q)
+
+	var v T
+	if cache.get(ns, key, gridCacheTTL, &v) {
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: grid cache hit %s %s\n", ns, key)
+		}
+		return v, nil
+	}
+
+	v, err := fetch()
+	if err != nil {
+		return v, err
+	}
+	if err := cache.put(ns, key, v); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// 같은 격자 칸(0.1°)의 좌표는 캐시 항목 하나를 같이 쓰고, 이웃 칸이나 다른 단위는 따로 받는다.
+func TestCachedByGridSharesCell(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	fixNow(t, now)
+	opts := testOptions()
+	opts.NoCache = false
+	opts.CacheDir = t.TempDir()
+	opts.GridCache = 0.1
+
+	imperial := testOptions()
+	imperial.Unit = "f"
+
+	cases := []struct {
+		name     string
+		lat, lon float64
+		q        queryOpts
+		fetch    bool // 새로 받아야 하는지
+		elapsed  time.Duration
+	}{
+		{"seoul", 37.566, 126.9784, forecastQuery(opts), true, 0},
+		{"seoul city hall", 37.5663, 126.9779, forecastQuery(opts), false, 0},
+		{"same cell edge", 37.64, 127.04, forecastQuery(opts), false, 0},
+		{"neighbour cell (lat)", 37.66, 126.9784, forecastQuery(opts), true, 0},
+		{"neighbour cell (lon)", 37.566, 127.06, forecastQuery(opts), true, 0},
+		{"other units", 37.566, 126.9784, forecastQuery(imperial), true, 0},
+		{"after ttl", 37.5663, 126.9779, forecastQuery(opts), true, gridCacheTTL + time.Minute},
+	}
+	var fetches int
+	for _, c := range cases {
+		fixNow(t, now.Add(c.elapsed))
+		before := fetches
+		got, err := cachedByGrid(opts, "forecast", c.lat, c.lon, c.q, func() (Current, error) {
+			fetches++
+			return Current{Temperature2m: float64(fetches)}, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched := fetches > before; fetched != c.fetch {
+			t.Errorf("%s: fetched = %v, want %v (key %s)", c.name, fetched, c.fetch, gridKey(c.lat, c.lon, opts.GridCache, c.q))
+		}
+		if !c.fetch && got.Temperature2m != 1 {
+			t.Errorf("%s: got entry %v, want the first cached one", c.name, got.Temperature2m)
+		}
+	}
+}
+
+func TestGridCell(t *testing.T) {
+	cases := []struct {
+		v, step float64
+		want    string
+	}{
+		{37.566, 0.1, "37.6000"},
+		{37.549, 0.1, "37.5000"},
+		{-0.04, 0.1, "0.0000"}, // -0 이 아니라 0
+		{0.04, 0.1, "0.0000"},
+		{126.9784, 0.5, "127.0000"},
+		{-74.006, 0.01, "-74.0100"},
+	}
+	for _, c := range cases {
+		if got := gridCell(c.v, c.step); got != c.want {
+			t.Errorf("gridCell(%v, %v) = %s, want %s", c.v, c.step, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ---------- Heat index ----------
+// 열지수는 덥고 습할 때만 의미가 있다. 기온 27°C, 습도 40% 미만이면 계산하지 않는다.
+// API의 apparent_temperature (바람/일사 포함)와는 다른 값이다.
+const (
+	heatIndexMinTempC    = 27
+	heatIndexMinHumidity = 40
+)
+
+func heatIndexApplies(tempC, humidity float64) bool {
+	return tempC >= heatIndexMinTempC && humidity >= heatIndexMinHumidity
+}
+
+// heatIndex는 미국 기상청(NWS) 열지수 (°C)다.
+// 간이식 결과가 80°F 이상이면 Rothfusz 회귀식과 보정항을 쓴다.
+func heatIndex(tempC, humidity float64) float64 {
+	t := tempC*9/5 + 32
+	rh := humidity
+
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+		switch {
+		case rh < 13 && t >= 80 && t <= 112:
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		case rh > 85 && t >= 80 && t <= 87:
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return fahrenheitToCelsius(hi)
+}
+
+// heatIndexText는 "체감(열지수) 35.2°C" 줄이다. 해당 조건이 아니면 "" 이다.
+func heatIndexText(w Current, opts Options) string {
+	if w.Humidity == nil {
+		return ""
+	}
+	tempC := float64(opts.celsius(w.Temperature2m))
+	if !heatIndexApplies(tempC, *w.Humidity) {
+		return ""
+	}
+
+	hi := heatIndex(tempC, *w.Humidity)
+	if opts.imperial() {
+		hi = hi*9/5 + 32
+	}
+	return fmt.Sprintf("체감(열지수) %s%s", opts.Precision.format("temp", hi), tempSymbol(opts))
+}
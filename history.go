@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// ---------- SQLite history ----------
+// --db <file> 은 조회마다 reports 테이블에 한 줄씩 쌓는다. (SQL로 조회할 수 있는 개인 기록용)
+// 순수 Go 드라이버 (modernc.org/sqlite)라 sqlite3 라이브러리나 명령이 없어도 된다.
+//
+//	sqlite3 weather.db "SELECT time, city, temperature FROM reports ORDER BY time DESC LIMIT 5"
+
+const historyTable = "reports"
+
+// 동시에 여러 프로세스가 쓰면 잠금이 풀릴 때까지 이만큼 기다린다. (busy_timeout, ms)
+const historyBusyTimeout = 5000
+
+// historyColumns는 reports 테이블의 열이다. 새 열은 끝에 더하면 기존 DB에 ALTER TABLE 로 붙는다.
+var historyColumns = []struct {
+	name, typ string
+	value     func(r Report, opts Options) any
+}{
+	{"time", "TEXT NOT NULL", func(r Report, _ Options) any { return r.Time.Format(time.RFC3339) }},
+	{"city", "TEXT", func(r Report, _ Options) any { return r.Location.Name }},
+	{"country_code", "TEXT", func(r Report, _ Options) any { return r.Location.CountryCode }},
+	{"latitude", "REAL", func(r Report, _ Options) any { return r.Location.Latitude }},
+	{"longitude", "REAL", func(r Report, _ Options) any { return r.Location.Longitude }},
+	{"unit", "TEXT", func(_ Report, opts Options) any { return opts.Unit }},
+	{"temperature", "REAL", weatherField(func(w Current) any { return w.Temperature2m })},
+	{"apparent_temperature", "REAL", weatherField(func(w Current) any { return w.ApparentTemperature })},
+	{"weather_code", "INTEGER", weatherField(func(w Current) any { return w.WeatherCode })},
+	{"precipitation_probability", "REAL", weatherField(func(w Current) any { return w.PrecipProbability })},
+	{"precipitation_mm", "REAL", weatherField(func(w Current) any { return w.Precipitation })},
+	{"visibility_m", "REAL", weatherField(func(w Current) any { return w.Visibility })},
+	{"uv_index", "REAL", weatherField(func(w Current) any { return w.UVIndex })},
+	{"humidity", "REAL", weatherField(func(w Current) any { return w.Humidity })},
+	{"wind_speed", "REAL", weatherField(func(w Current) any { return w.WindSpeed })},
+	{"us_aqi", "INTEGER", airField(func(a AirQualityCurrent) any { return a.AQIUS })},
+	{"european_aqi", "INTEGER", airField(func(a AirQualityCurrent) any { return a.AQIEU })},
+	{"pm10", "REAL", airField(func(a AirQualityCurrent) any { return a.PM10 })},
+	{"pm2_5", "REAL", airField(func(a AirQualityCurrent) any { return a.PM25 })},
+}
+
+// weatherField, airField는 해당 섹션이 실패했으면 NULL을 넣는다.
+func weatherField(f func(Current) any) func(Report, Options) any {
+	return func(r Report, _ Options) any {
+		if r.WeatherErr != nil {
+			return nil
+		}
+		return f(r.Weather)
+	}
+}
+
+func airField(f func(AirQualityCurrent) any) func(Report, Options) any {
+	return func(r Report, _ Options) any {
+		if r.AirErr != nil {
+			return nil
+		}
+		return f(r.Air)
+	}
+}
+
+// appendHistory는 테이블이 없으면 만들고, 빠진 열은 더한 뒤 r을 한 줄 넣는다.
+func appendHistory(ctx context.Context, path string, r Report, opts Options) error {
+	db, err := openHistory(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := migrateHistory(ctx, db); err != nil {
+		return fmt.Errorf("history schema migrate failed (%s): %w", path, err)
+	}
+
+	names := make([]string, len(historyColumns))
+	marks := make([]string, len(historyColumns))
+	values := make([]any, len(historyColumns))
+	for i, c := range historyColumns {
+		names[i], marks[i], values[i] = c.name, "?", c.value(r, opts)
+	}
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", historyTable, strings.Join(names, ", "), strings.Join(marks, ", "))
+	if _, err := db.ExecContext(ctx, stmt, values...); err != nil {
+		return fmt.Errorf("history insert failed (%s): %w", path, err)
+	}
+	return nil
+}
+
+// openHistory는 path의 SQLite 파일을 연다. (없으면 만든다)
+// 트랜잭션은 BEGIN IMMEDIATE 로 시작해, 여러 프로세스가 동시에 migrate 해도 busy_timeout 안에서 차례를 기다린다.
+func openHistory(path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("%s?_pragma=busy_timeout(%d)&_txlock=immediate", path, historyBusyTimeout)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("history open failed (%s): %w", path, err)
+	}
+	return db, nil
+}
+
+// migrateHistory는 한 트랜잭션 안에서 CREATE TABLE 후 기존 테이블에 없는 열을 ALTER TABLE 로 더한다.
+func migrateHistory(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	defs := []string{"id INTEGER PRIMARY KEY"}
+	for _, c := range historyColumns {
+		defs = append(defs, c.name+" "+c.typ)
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", historyTable, strings.Join(defs, ", "))); err != nil {
+		return err
+	}
+
+	have, err := historyColumnNames(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, c := range historyColumns {
+		if have[c.name] {
+			continue
+		}
+		// NOT NULL 열은 기본값 없이 더할 수 없으므로 타입만 쓴다.
+		typ, _, _ := strings.Cut(c.typ, " ")
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", historyTable, c.name, typ)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func historyColumnNames(ctx context.Context, tx *sql.Tx) (map[string]bool, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", historyTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	have := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		have[name] = true
+	}
+	return have, rows.Err()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAppendHistory(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	path := filepath.Join(t.TempDir(), "weather.db")
+	ctx := context.Background()
+
+	r := testReport()
+	r.Weather.PrecipProbability = nil // NULL로 들어가야 한다.
+	if err := appendHistory(ctx, path, r, testOptions()); err != nil {
+		t.Fatalf("appendHistory: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var (
+		when, city string
+		temp       float64
+		aqi        int
+		precip     sql.NullFloat64
+	)
+	err = db.QueryRow("SELECT time, city, temperature, us_aqi, precipitation_probability FROM reports").
+		Scan(&when, &city, &temp, &aqi, &precip)
+	if err != nil {
+		t.Fatalf("read back: %v", err)
+	}
+	if when != "2025-03-14T15:30:00+09:00" || city != "서울" || temp != 12.3 || aqi != 72 || precip.Valid {
+		t.Errorf("row = %s %s %v %d %v", when, city, temp, aqi, precip)
+	}
+}
+
+// 예전 스키마 (열이 적은 테이블)에도 빠진 열을 더하고 넣을 수 있어야 한다.
+func TestAppendHistoryMigratesOldSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather.db")
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE reports (id INTEGER PRIMARY KEY, time TEXT NOT NULL, city TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO reports (time, city) VALUES ('2024-01-01T00:00:00+09:00', '부산')"); err != nil {
+		t.Fatal(err)
+	}
+
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	if err := appendHistory(ctx, path, testReport(), testOptions()); err != nil {
+		t.Fatalf("appendHistory: %v", err)
+	}
+
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM reports WHERE pm2_5 IS NOT NULL").Scan(&n); err != nil {
+		t.Fatalf("migrated column missing: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("rows with pm2_5 = %d, want 1", n)
+	}
+}
+
+func TestAppendHistoryConcurrent(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	path := filepath.Join(t.TempDir(), "weather.db")
+
+	const writers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := testReport()
+			r.Location.Name = fmt.Sprintf("city-%d", i)
+			errs <- appendHistory(context.Background(), path, r, testOptions())
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("appendHistory: %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM reports").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != writers {
+		t.Errorf("rows = %d, want %d", n, writers)
+	}
+}
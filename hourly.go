@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------- hourly 명령 ----------
+const (
+	defaultHourlyHours = 24
+	maxHourlyHours     = 48
+
+	sparkHeight = 8
+	sparkGutter = 6 // y축 라벨 "%4.0f |"
+)
+
+type hourlyPoint struct {
+	Time time.Time
+	Temp float64
+}
+
+type hourlyResponse struct {
+	Hourly struct {
+		Time        []string   `json:"time"`
+		Temperature []*float64 `json:"temperature_2m"`
+	} `json:"hourly"`
+}
+
+func hourlyQuery(opts Options, hours int) queryOpts {
+	q := queryOpts{
+		Timezone:      "Asia/Seoul",
+		Hourly:        []string{"temperature_2m"},
+		ForecastHours: hours,
+	}
+	if opts.imperial() {
+		q.TemperatureUnit = "fahrenheit"
+	}
+	return q
+}
+
+// RunHourly는 앞으로 hours시간의 기온을 목록 또는 (graph면) 차트로 출력한다.
+// 차트는 터미널일 때만 그린다. forceGraph면 파이프에서도 그린다.
+func RunHourly(ctx context.Context, city string, hours int, graph, forceGraph bool, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	loc, err := resolveCity(ctx, client, city, opts)
+	if err != nil {
+		return opts.deadlineErr(ctx, err)
+	}
+	if opts.Unit == unitAuto {
+		opts.Unit = defaultUnitForCountry(loc.CountryCode)
+	}
+
+	q := hourlyQuery(opts, hours)
+	if opts.DryRun {
+		fmt.Println("GET " + buildForecastURL(endpoints.Forecast[0], loc.Latitude, loc.Longitude, q))
+		return nil
+	}
+
+	fctx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.ForecastTimeout))
+	defer cancel()
+
+	points, err := fetchHourly(fctx, client, loc.Latitude, loc.Longitude, q)
+	if err != nil {
+		return opts.deadlineErr(ctx, err)
+	}
+
+	fmt.Printf("%s 시간별 기온\n", loc.Name)
+	if graph && (forceGraph || isTerminal(os.Stdout)) {
+		printHourlyGraph(points, terminalWidth(), opts)
+		return nil
+	}
+	printHourlyList(points, opts)
+	return nil
+}
+
+func fetchHourly(ctx context.Context, client *http.Client, lat, lon float64, q queryOpts) ([]hourlyPoint, error) {
+	urls := mirrorURLs(endpoints.Forecast, func(base string) string {
+		return buildForecastURL(base, lat, lon, q)
+	})
+	resp, err := fetchWithFallback(ctx, client, urls)
+	if err != nil {
+		return nil, requestError("hourly", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("hourly", resp)
+	}
+
+	var data hourlyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, decodeError("hourly", err)
+	}
+
+	var points []hourlyPoint
+	for i, s := range data.Hourly.Time {
+		if i >= len(data.Hourly.Temperature) || data.Hourly.Temperature[i] == nil {
+			continue
+		}
+		t, err := time.ParseInLocation(openMeteoTimeLayout, s, kst)
+		if err != nil {
+			continue
+		}
+		points = append(points, hourlyPoint{Time: t, Temp: *data.Hourly.Temperature[i]})
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("hourly: no data")
+	}
+	return points, nil
+}
+
+func printHourlyList(points []hourlyPoint, opts Options) {
+	unit := tempSymbol(opts)
+	for _, pt := range points {
+		fmt.Printf("%s  %s%s\n", pt.Time.Format("01-02 15시"), opts.Precision.format("temp", pt.Temp), unit)
+	}
+}
+
+// printHourlyGraph는 차트 아래에 3시간마다 시각 눈금을 붙인다.
+func printHourlyGraph(points []hourlyPoint, width int, opts Options) {
+	n, step := sparkLayout(len(points), width)
+	points = points[:n]
+	values := make([]float64, n)
+	for i, pt := range points {
+		values[i] = pt.Temp
+	}
+	fmt.Print(renderSparkChart(values, width, sparkHeight))
+
+	axis := []byte(strings.Repeat(" ", sparkGutter+n*step+2))
+	for i := 0; i < n; i += 3 {
+		copy(axis[sparkGutter+i*step:], points[i].Time.Format("15"))
+	}
+	fmt.Println(strings.TrimRight(string(axis), " ") + "  (시)")
+	fmt.Printf("최저 %s%s / 최고 %s%s\n",
+		opts.Precision.format("temp", minOf(values)), tempSymbol(opts),
+		opts.Precision.format("temp", maxOf(values)), tempSymbol(opts))
+}
+
+// ---------- Spark chart ----------
+// sparkLayout은 width 안에 그릴 값 개수와 값 하나의 칸 수를 돌려준다.
+// 칸이 모자라면 앞에서부터 들어가는 만큼만 그린다.
+func sparkLayout(n, width int) (count, step int) {
+	plot := max(width-sparkGutter, 1)
+	if n > plot {
+		return plot, 1
+	}
+	return n, plot / max(n, 1)
+}
+
+// renderSparkChart는 values를 height줄짜리 점 그래프로 그린다. (맨 윗줄 = 최고, 맨 아랫줄 = 최저)
+// 모든 값이 같으면 가운데 줄에 평평한 선이 된다.
+func renderSparkChart(values []float64, width, height int) string {
+	if len(values) == 0 || height < 1 {
+		return ""
+	}
+	n, step := sparkLayout(len(values), width)
+	values = values[:n]
+	lo, hi := minOf(values), maxOf(values)
+
+	grid := make([][]byte, height)
+	for r := range grid {
+		grid[r] = []byte(strings.Repeat(" ", n*step))
+	}
+	for i, v := range values {
+		row := (height - 1) / 2
+		if hi > lo {
+			row = height - 1 - int(math.Round((v-lo)/(hi-lo)*float64(height-1)))
+		}
+		for c := i * step; c < (i+1)*step; c++ {
+			grid[row][c] = '*'
+		}
+	}
+
+	var b strings.Builder
+	for r, line := range grid {
+		label := ""
+		switch r {
+		case 0:
+			label = strconv.FormatFloat(hi, 'f', 0, 64)
+		case height - 1:
+			label = strconv.FormatFloat(lo, 'f', 0, 64)
+		}
+		fmt.Fprintf(&b, "%4s |%s\n", label, strings.TrimRight(string(line), " "))
+	}
+	fmt.Fprintf(&b, "%4s +%s\n", "", strings.Repeat("-", n*step))
+	return b.String()
+}
+
+func minOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Min(m, v)
+	}
+	return m
+}
+
+func maxOf(values []float64) float64 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = math.Max(m, v)
+	}
+	return m
+}
+
+// terminalWidth는 $COLUMNS를 보고, 없으면 80칸으로 본다.
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > sparkGutter {
+		return n
+	}
+	return 80
+}
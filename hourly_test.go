@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSparkChart(t *testing.T) {
+	cases := []struct {
+		name          string
+		values        []float64
+		width, height int
+		want          string
+	}{
+		{
+			"flat series is a flat middle line", []float64{5, 5, 5, 5}, 20, 5,
+			"   5 |\n" +
+				"     |\n" +
+				"     |************\n" +
+				"     |\n" +
+				"   5 |\n" +
+				"     +------------\n",
+		},
+		{
+			"rising", []float64{1, 2, 3, 4, 5}, 20, 5,
+			"   5 |        **\n" +
+				"     |      **\n" +
+				"     |    **\n" +
+				"     |  **\n" +
+				"   1 |**\n" +
+				"     +----------\n",
+		},
+		{
+			"falling", []float64{3, 1}, 20, 3,
+			"   3 |*******\n" +
+				"     |\n" +
+				"   1 |       *******\n" +
+				"     +--------------\n",
+		},
+		{
+			"truncated to width", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sparkGutter + 5, 2,
+			"   5 |  ***\n" +
+				"   1 |**\n" +
+				"     +-----\n",
+		},
+		{"empty", nil, 20, 5, ""},
+		{"no rows", []float64{1, 2}, 20, 0, ""},
+	}
+	for _, c := range cases {
+		if got := renderSparkChart(c.values, c.width, c.height); got != c.want {
+			t.Errorf("%s:\n--- got\n%s--- want\n%s", c.name, got, c.want)
+		}
+	}
+}
+
+// 평평한 값은 높이와 상관없이 한 줄에만 점이 찍힌다.
+func TestRenderSparkChartFlatAnyHeight(t *testing.T) {
+	for height := 1; height <= 8; height++ {
+		chart := renderSparkChart([]float64{-3, -3, -3}, 40, height)
+		var plotted int
+		for _, line := range strings.Split(chart, "\n") {
+			if strings.Contains(line, "*") {
+				plotted++
+			}
+		}
+		if plotted != 1 {
+			t.Errorf("height %d: %d rows plotted, want 1\n%s", height, plotted, chart)
+		}
+	}
+}
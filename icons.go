@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ---------- Icon sets ----------
+// emoji: 기본. ascii: 이모지를 못 그리는 콘솔용 (한글 라벨은 그대로 둔다)
+const (
+	iconSetAuto  = "auto"
+	iconSetEmoji = "emoji"
+	iconSetASCII = "ascii"
+)
+
+// detectIconSet은 터미널이 이모지를 그릴 수 있는지 추정한다. (best-effort)
+//
+//  1. LC_ALL > LC_CTYPE > LANG 중 처음 설정된 값에 UTF-8이 있으면 emoji,
+//     UTF-8이 아닌 값(C, POSIX, ko_KR.EUC-KR 등)이면 ascii
+//  2. 모두 비어 있으면: Windows는 Windows Terminal($WT_SESSION)일 때만 emoji,
+//     그 외 OS는 emoji
+//
+// 추정이 틀리면 --icon-set 으로 덮어쓴다.
+func detectIconSet(getenv func(string) string, goos string) string {
+	for _, k := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		v := strings.ToLower(getenv(k))
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "utf-8") || strings.Contains(v, "utf8") {
+			return iconSetEmoji
+		}
+		return iconSetASCII
+	}
+
+	if goos == "windows" && getenv("WT_SESSION") == "" {
+		return iconSetASCII
+	}
+	return iconSetEmoji
+}
+
+func (o Options) ascii() bool {
+	return o.IconSet == iconSetASCII
+}
+
+// conditionText는 아이콘 세트에 맞는 "아이콘  라벨"을 돌려준다.
+//
+// 이모지 아이콘은 1칸으로 그리는 터미널이 많아 뒤에 공백을 두 칸 둔다.
+// 2칸으로 제대로 그리는 터미널이면 --no-emoji-width-hack 으로 한 칸만 쓴다.
+func conditionText(code int, isNight bool, opts Options) string {
+	if opts.ascii() {
+		return overrideLabel(iconForCodeASCII(code, isNight), code, isNight)
+	}
+	s := overrideLabel(iconForCode(code, isNight), code, isNight)
+	if opts.NoEmojiWidthHack {
+		s = strings.Replace(s, "  ", " ", 1)
+	}
+	return s
+}
+
+// overrideLabel은 threshold 파일의 condition_labels가 있으면 "아이콘  라벨"의 라벨을 바꾼다.
+// 밤에는 "0n" 을 먼저 보고, 없으면 "0" 을 본다.
+func overrideLabel(s string, code int, isNight bool) string {
+	labels := thresholds.ConditionLabels
+	if len(labels) == 0 {
+		return s
+	}
+	key := strconv.Itoa(code)
+	label, ok := labels[key+"n"]
+	if !ok || !isNight {
+		label, ok = labels[key]
+	}
+	if !ok {
+		return s
+	}
+	icon, _, found := strings.Cut(s, "  ")
+	if !found {
+		return s
+	}
+	return icon + "  " + label
+}
+
+func iconForCodeASCII(code int, isNight bool) string {
+	return lookupCode(code).icon(isNight, true)
+}
+
+func aqiText(aqi int, opts Options) string {
+	if opts.ascii() {
+		return aqiLabel(aqi)
+	}
+	return aqiStatus(aqi)
+}
+
+func tipPrefix(opts Options) string {
+	if opts.ascii() {
+		return "-"
+	}
+	return "💡"
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// 맑음/흐림은 밤에 달/흐린 밤으로 바뀌고, 나머지 코드는 낮과 같다.
+func TestIconDayNight(t *testing.T) {
+	cases := []struct {
+		code                 int
+		day, night           string
+		dayASCII, nightASCII string
+	}{
+		{0, "☀️  맑음", "🌙  맑은 밤", "[*]  맑음", "[)]  맑은 밤"},
+		{2, "☁️  흐림", "☁️  흐린 밤", "[~]  흐림", "[~]  흐린 밤"},
+		{3, "☁️  흐림", "☁️  흐린 밤", "[~]  흐림", "[~]  흐린 밤"},
+		{45, "🌫️  안개", "🌫️  안개", "[=]  안개", "[=]  안개"},
+		{63, "🌧️  비", "🌧️  비", "[/]  비", "[/]  비"},
+	}
+	for _, c := range cases {
+		if got := iconForCode(c.code, false); got != c.day {
+			t.Errorf("%d day = %q, want %q", c.code, got, c.day)
+		}
+		if got := iconForCode(c.code, true); got != c.night {
+			t.Errorf("%d night = %q, want %q", c.code, got, c.night)
+		}
+		if got := iconForCodeASCII(c.code, false); got != c.dayASCII {
+			t.Errorf("%d ascii day = %q, want %q", c.code, got, c.dayASCII)
+		}
+		if got := iconForCodeASCII(c.code, true); got != c.nightASCII {
+			t.Errorf("%d ascii night = %q, want %q", c.code, got, c.nightASCII)
+		}
+	}
+}
+
+// 일몰 시각부터 다음 일출 전까지가 밤이다. 일출/일몰을 모르면 낮으로 본다.
+func TestIsNight(t *testing.T) {
+	day := time.Date(2025, 3, 14, 0, 0, 0, 0, kst)
+	w := Current{Sunrise: day.Add(6*time.Hour + 45*time.Minute), Sunset: day.Add(18*time.Hour + 35*time.Minute)}
+	cases := []struct {
+		at   time.Duration
+		want bool
+	}{
+		{2 * time.Hour, true},
+		{6*time.Hour + 44*time.Minute, true},
+		{6*time.Hour + 45*time.Minute, false},
+		{12 * time.Hour, false},
+		{18*time.Hour + 34*time.Minute, false},
+		{18*time.Hour + 35*time.Minute, true},
+		{23 * time.Hour, true},
+	}
+	for _, c := range cases {
+		if got := w.isNight(day.Add(c.at)); got != c.want {
+			t.Errorf("isNight(%s) = %v, want %v", day.Add(c.at).Format("15:04"), got, c.want)
+		}
+	}
+	if (Current{}).isNight(day.Add(23 * time.Hour)) {
+		t.Error("unknown sun times should count as day")
+	}
+}
+
+// 같은 맑음(0)이라도 요약 첫 줄은 낮에 ☀️, 밤에 🌙 이다.
+func TestSummaryNightIcon(t *testing.T) {
+	cases := []struct {
+		hour int
+		want string
+	}{
+		{15, "☀️  맑음"},
+		{23, "🌙  맑은 밤"},
+	}
+	for _, c := range cases {
+		fixNow(t, time.Date(2025, 3, 14, c.hour, 30, 0, 0, kst))
+		got := captureStdout(t, func() { printSummary(testReport(), testOptions()) })
+		lines := strings.Split(got, "\n")
+		if len(lines) < 2 || !strings.HasPrefix(lines[1], c.want) {
+			t.Errorf("%d시: weather line = %q, want prefix %q", c.hour, lines[1], c.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ---------- init 명령 ----------
+// runInitWizard는 기본 도시/단위/언어를 물어보고, 한 번 조회해 확인한 뒤 설정 파일을 쓴다.
+// 입출력은 io.Reader/io.Writer로 받아 테스트에서 대체할 수 있다.
+func runInitWizard(ctx context.Context, in io.Reader, out io.Writer, path string) error {
+	sc := bufio.NewScanner(in)
+
+	if _, err := os.Stat(path); err == nil {
+		ok, err := promptYesNo(sc, out, fmt.Sprintf("%s 이(가) 이미 있습니다. 덮어쓸까요?", path))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, "취소했습니다.")
+			return nil
+		}
+	}
+
+	var c Config
+	if old, err := loadConfig(path); err == nil {
+		c.Profiles = old.Profiles // 프로필은 그대로 둔다.
+	}
+	for c.City == "" {
+		city, err := prompt(sc, out, "기본 도시", "")
+		if err != nil {
+			return err
+		}
+		c.City = city
+	}
+
+	for {
+		u, err := prompt(sc, out, "온도 단위 (c/f/auto)", "c")
+		if err != nil {
+			return err
+		}
+		u = strings.ToLower(u)
+		if validUnit(u) {
+			c.Unit = u
+			break
+		}
+		fmt.Fprintln(out, "c, f, auto 중 하나를 입력하세요.")
+	}
+
+	for {
+		l, err := prompt(sc, out, "언어 (ko/en)", "ko")
+		if err != nil {
+			return err
+		}
+		l = strings.ToLower(l)
+		if validLang(l) {
+			c.Lang = l
+			break
+		}
+		fmt.Fprintln(out, "ko 또는 en 을 입력하세요.")
+	}
+
+	fmt.Fprintln(out, "")
+	opts := Options{Unit: c.Unit, Lang: c.Lang, Precision: newPrecision(), DetailLevel: detailDefault}
+	if err := RunNow(ctx, c.City, opts); err != nil {
+		return fmt.Errorf("verification fetch failed, config not saved: %w", err)
+	}
+	fmt.Fprintln(out, "")
+
+	if err := saveConfig(path, c); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "저장했습니다: %s\n", path)
+	return nil
+}
+
+// prompt는 한 줄을 읽는다. 빈 입력이면 def를 돌려준다.
+func prompt(sc *bufio.Scanner, out io.Writer, label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(out, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(out, "%s: ", label)
+	}
+
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("input closed")
+	}
+
+	v := strings.TrimSpace(sc.Text())
+	if v == "" {
+		return def, nil
+	}
+	return v, nil
+}
+
+func promptYesNo(sc *bufio.Scanner, out io.Writer, label string) (bool, error) {
+	v, err := prompt(sc, out, label+" (y/N)", "n")
+	if err != nil {
+		return false, err
+	}
+	v = strings.ToLower(v)
+	return v == "y" || v == "yes", nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ---------- IP geolocation (--here) ----------
+// 테스트에서 바꿀 수 있도록 변수로 둔다.
+var ipGeoURL = "https://ipapi.co/json/"
+
+type ipGeoResponse struct {
+	City        string  `json:"city"`
+	Region      string  `json:"region"`
+	CountryName string  `json:"country_name"`
+	CountryCode string  `json:"country_code"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// geolocateByIP는 공인 IP로 대략적인 위치를 얻는다. (도시 수준, 수 km 이상 오차)
+func geolocateByIP(ctx context.Context, client *http.Client) (GeoResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ipGeoURL, nil)
+	if err != nil {
+		return GeoResult{}, fmt.Errorf("ip geolocation request failed: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return GeoResult{}, fmt.Errorf("ip geolocation request failed (도시 이름을 직접 입력해 보세요): %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return GeoResult{}, fmt.Errorf("ip geolocation bad status: %s", resp.Status)
+	}
+
+	var data ipGeoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return GeoResult{}, fmt.Errorf("ip geolocation decode failed: %w", err)
+	}
+
+	r := GeoResult{
+		Name:        data.City,
+		Country:     data.CountryName,
+		CountryCode: data.CountryCode,
+		Admin1:      data.Region,
+		Latitude:    data.Latitude,
+		Longitude:   data.Longitude,
+		Approximate: true,
+	}
+	if r.Name == "" {
+		r.Name = "현재 위치"
+	}
+	if !validGeoResult(r) {
+		return GeoResult{}, fmt.Errorf("ip geolocation returned no usable location (도시 이름을 직접 입력해 보세요)")
+	}
+	return r, nil
+}
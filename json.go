@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ---------- JSON output ----------
+// jsonSchemaVersion은 SummaryJSON 형식 버전이다.
+// 필드를 빼거나 이름/타입을 바꾸면 올린다. (필드 추가만은 그대로)
+// 1.1: weather.precipitation_probability 가 null 일 수 있다.
+const jsonSchemaVersion = "1.1"
+
+// SummaryJSON은 --json 출력과 --webhook 본문에 쓰는 안정적인 형태다.
+type SummaryJSON struct {
+	SchemaVersion string `json:"schema_version"`
+
+	City      string  `json:"city"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Time      string  `json:"time"` // RFC3339 (KST)
+
+	// Units는 값들의 단위다. --unit 등 화면 출력과 같은 설정에서 만든다.
+	Units UnitsJSON `json:"units"`
+
+	Weather  *WeatherJSON  `json:"weather,omitempty"`
+	Alerts   []tempAlert   `json:"alerts,omitempty"`
+	Air      *AirJSON      `json:"air,omitempty"`
+	VsNormal *VsNormalJSON `json:"vs_normal,omitempty"`
+
+	// Meta는 API 응답 헤더 (재현/지원 문의용)다. 없으면 생략한다.
+	Meta *MetaJSON `json:"_meta,omitempty"`
+}
+
+type MetaJSON struct {
+	Responses []apiMeta `json:"responses"`
+}
+
+type WeatherJSON struct {
+	Temperature         float64  `json:"temperature"`
+	ApparentTemperature float64  `json:"apparent_temperature"`
+	PrecipProbability   *float64 `json:"precipitation_probability"`
+	WeatherCode         int      `json:"weather_code"`
+	Condition           string   `json:"condition"`
+	VisibilityM         *float64 `json:"visibility_m,omitempty"`
+	UVIndex             *float64 `json:"uv_index,omitempty"`
+	PrecipitationMM     *float64 `json:"precipitation_mm,omitempty"`
+	WindSpeed           *float64 `json:"wind_speed,omitempty"`
+}
+
+type AirJSON struct {
+	USAQI            int      `json:"us_aqi"`
+	USAQIEstimated   bool     `json:"us_aqi_estimated,omitempty"`
+	USAQIGrade       string   `json:"us_aqi_grade"`
+	EuropeanAQI      *int     `json:"european_aqi,omitempty"`
+	EuropeanAQIGrade string   `json:"european_aqi_grade,omitempty"`
+	PM10             float64  `json:"pm10"`
+	PM10Grade        string   `json:"pm10_grade"`
+	PM25             float64  `json:"pm2_5"`
+	PM25Grade        string   `json:"pm2_5_grade"`
+	PM25Avg24h       *float64 `json:"pm2_5_avg_24h,omitempty"`
+	PM25AvgHours     int      `json:"pm2_5_avg_hours,omitempty"`
+
+	// RawNegative는 0으로 자르기 전의 음수 원값이다. (--clamp-negative-aqi)
+	RawNegative map[string]float64 `json:"raw_negative,omitempty"`
+}
+
+type UnitsJSON struct {
+	Temperature   string `json:"temperature"` // temperature, apparent_temperature, vs_normal, alerts
+	Wind          string `json:"wind"`
+	Precipitation string `json:"precipitation"` // precipitation_mm 은 단위와 관계없이 mm
+	Probability   string `json:"probability"`
+	Visibility    string `json:"visibility"`
+	PM            string `json:"pm"`
+}
+
+func newUnitsJSON(opts Options) UnitsJSON {
+	return UnitsJSON{
+		Temperature:   tempSymbol(opts),
+		Wind:          windSymbol(opts),
+		Precipitation: "mm",
+		Probability:   "%",
+		Visibility:    "m",
+		PM:            "µg/m³",
+	}
+}
+
+type VsNormalJSON struct {
+	Mean  float64 `json:"mean"`
+	Diff  float64 `json:"diff"`
+	Years int     `json:"years"`
+}
+
+func newSummaryJSON(r Report, opts Options) SummaryJSON {
+	s := SummaryJSON{
+		SchemaVersion: jsonSchemaVersion,
+		City:          r.Location.Name,
+		Country:       r.Location.Country,
+		Latitude:      r.Location.Latitude,
+		Longitude:     r.Location.Longitude,
+		Time:          r.Time.Format(time.RFC3339),
+		Units:         newUnitsJSON(opts),
+	}
+
+	if r.WeatherErr == nil {
+		w := r.Weather
+		s.Alerts = tempAlerts(w, opts)
+		s.Weather = &WeatherJSON{
+			Temperature:         w.Temperature2m,
+			ApparentTemperature: w.ApparentTemperature,
+			PrecipProbability:   w.PrecipProbability,
+			WeatherCode:         w.WeatherCode,
+			Condition:           conditionEN(w.WeatherCode),
+			VisibilityM:         w.Visibility,
+			UVIndex:             w.UVIndex,
+			PrecipitationMM:     w.Precipitation,
+			WindSpeed:           w.WindSpeed,
+		}
+		if r.Normal != nil {
+			s.VsNormal = &VsNormalJSON{
+				Mean:  r.Normal.Mean,
+				Diff:  w.Temperature2m - r.Normal.Mean,
+				Years: r.Normal.Years,
+			}
+		}
+	}
+
+	if r.AirErr == nil {
+		aq := r.Air
+		aqi, estimated := aq.usAQI()
+		pm10, _, _ := pm10GradeKR(aq.PM10)
+		pm25, _, _ := pm25GradeKR(aq.PM25)
+		air := &AirJSON{
+			USAQI:          aqi,
+			USAQIEstimated: estimated,
+			USAQIGrade:     aqiLabel(aqi),
+			PM10:           aq.PM10,
+			PM10Grade:      pm10,
+			PM25:           aq.PM25,
+			PM25Grade:      pm25,
+		}
+		if aq.PM25Avg24h != nil {
+			air.PM25Avg24h, air.PM25AvgHours = aq.PM25Avg24h, aq.PM25AvgHours
+		}
+		air.RawNegative = aq.Clamped
+		if aq.AQIEU != nil {
+			air.EuropeanAQI = aq.AQIEU
+			air.EuropeanAQIGrade = aqiStatusEU(*aq.AQIEU)
+		}
+		s.Air = air
+	}
+	if len(r.Meta) > 0 {
+		s.Meta = &MetaJSON{Responses: r.Meta}
+	}
+	return s
+}
+
+// jsonOutputter는 SummaryJSON을 들여쓰기 해서 출력한다.
+// compact면 (--json-compact, --json-lines) 한 줄로 출력한다. stdout은 버퍼링하지 않으므로 결과마다 바로 나간다.
+type jsonOutputter struct {
+	opts    Options
+	compact bool
+}
+
+func (o jsonOutputter) Output(r Report) error {
+	var (
+		b   []byte
+		err error
+	)
+	if o.compact {
+		b, err = json.Marshal(newSummaryJSON(r, o.opts))
+	} else {
+		b, err = json.MarshalIndent(newSummaryJSON(r, o.opts), "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("json encode failed: %w", err)
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(b))
+	return err
+}
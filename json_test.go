@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONCompactVsIndented(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	r := testReport()
+
+	indented := captureStdout(t, func() {
+		if err := newOutputter(Options{JSON: true, Unit: "c", Lang: "ko"}).Output(r); err != nil {
+			t.Fatal(err)
+		}
+	})
+	compact := captureStdout(t, func() {
+		if err := newOutputter(Options{JSON: true, JSONCompact: true, Unit: "c", Lang: "ko"}).Output(r); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if n := strings.Count(compact, "\n"); n != 1 || !strings.HasSuffix(compact, "\n") {
+		t.Errorf("compact output has %d newlines, want one trailing: %q", n, compact)
+	}
+	if !strings.Contains(indented, "\n  \"") {
+		t.Errorf("default output is not indented: %q", indented)
+	}
+	if !json.Valid([]byte(compact)) || !json.Valid([]byte(indented)) {
+		t.Fatal("output is not a complete JSON object")
+	}
+
+	// 들여쓰기만 다르고 내용은 같아야 한다.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(indented)); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != strings.TrimSpace(compact) {
+		t.Errorf("compact and indented differ\ncompact:  %s\nindented: %s", compact, buf.String())
+	}
+}
+
+// --json-lines 배치는 성공한 도시마다 들여쓰기 없는 JSON 한 줄이다. 실패한 도시는 stdout에 아무 것도 남기지 않는다.
+func TestRunBatchJSONLines(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	fakeOpenMeteo(t, batchPlaces)
+	opts := testOptions()
+	opts.JSON, opts.JSONLines = true, true
+
+	out := captureStdout(t, func() {
+		RunBatch(context.Background(), []string{"seoul", "nowhere", "busan"}, opts)
+	})
+
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("output does not end with a newline: %q", out)
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	var cities []string
+	for i, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Fatalf("line %d is not a JSON object: %q", i+1, line)
+		}
+		var s SummaryJSON
+		if err := json.Unmarshal([]byte(line), &s); err != nil {
+			t.Fatal(err)
+		}
+		cities = append(cities, s.City)
+	}
+	if got := strings.Join(cities, ","); got != "서울,부산" {
+		t.Errorf("cities = %s, want 서울,부산 (one line each)", got)
+	}
+}
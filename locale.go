@@ -0,0 +1,43 @@
+package main
+
+import "strings"
+
+// ---------- Locale ----------
+const langAuto = "auto"
+
+// detectLanguage는 LC_ALL > LC_MESSAGES > LANG 중 처음 설정된 값으로 언어를 고른다.
+// (ko_KR.UTF-8 → ko, en_US.UTF-8 → en) 모르는 언어나 C/POSIX는 ko 로 둔다.
+func detectLanguage(getenv func(string) string) string {
+	for _, k := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := strings.ToLower(getenv(k))
+		if v == "" {
+			continue
+		}
+		for _, l := range []string{"ko", "en"} {
+			if v == l || strings.HasPrefix(v, l+"_") || strings.HasPrefix(v, l+".") || strings.HasPrefix(v, l+"-") {
+				return l
+			}
+		}
+		return "ko"
+	}
+	return "ko"
+}
+
+// ---------- Unit by region ----------
+const unitAuto = "auto"
+
+// fahrenheitCountries는 화씨를 일상적으로 쓰는 나라다.
+var fahrenheitCountries = map[string]bool{
+	"US": true, // 미국
+	"LR": true, // 라이베리아
+	"MM": true, // 미얀마
+}
+
+// defaultUnitForCountry는 ISO 3166-1 alpha-2 국가 코드로 온도 단위를 고른다.
+// 모르거나 비어 있으면 섭씨다.
+func defaultUnitForCountry(code string) string {
+	if fahrenheitCountries[strings.ToUpper(code)] {
+		return "f"
+	}
+	return "c"
+}
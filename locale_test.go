@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"nothing set", nil, "ko"},
+		{"korean", map[string]string{"LANG": "ko_KR.UTF-8"}, "ko"},
+		{"english", map[string]string{"LANG": "en_US.UTF-8"}, "en"},
+		{"bare code", map[string]string{"LANG": "en"}, "en"},
+		{"dot form", map[string]string{"LANG": "en.UTF-8"}, "en"},
+		{"bcp47 form", map[string]string{"LANG": "en-GB"}, "en"},
+		{"upper case", map[string]string{"LANG": "EN_US.UTF-8"}, "en"},
+		{"C locale", map[string]string{"LANG": "C.UTF-8"}, "ko"},
+		{"POSIX", map[string]string{"LANG": "POSIX"}, "ko"},
+		{"unsupported", map[string]string{"LANG": "ja_JP.UTF-8"}, "ko"},
+		{"no en prefix match", map[string]string{"LANG": "eng"}, "ko"},
+		{"LC_ALL wins", map[string]string{"LC_ALL": "en_US.UTF-8", "LANG": "ko_KR.UTF-8"}, "en"},
+		{"LC_MESSAGES over LANG", map[string]string{"LC_MESSAGES": "en_US.UTF-8", "LANG": "ko_KR.UTF-8"}, "en"},
+		{"first set decides", map[string]string{"LC_ALL": "ja_JP.UTF-8", "LANG": "en_US.UTF-8"}, "ko"},
+		{"empty LC_ALL skipped", map[string]string{"LC_ALL": "", "LANG": "en_US.UTF-8"}, "en"},
+	}
+	for _, c := range cases {
+		getenv := func(k string) string { return c.env[k] }
+		if got := detectLanguage(getenv); got != c.want {
+			t.Errorf("%s: detectLanguage(%v) = %s, want %s", c.name, c.env, got, c.want)
+		}
+	}
+}
+
+func TestDefaultUnitForCountry(t *testing.T) {
+	cases := map[string]string{"US": "f", "us": "f", "LR": "f", "MM": "f", "KR": "c", "GB": "c", "": "c"}
+	for code, want := range cases {
+		if got := defaultUnitForCountry(code); got != want {
+			t.Errorf("defaultUnitForCountry(%q) = %s, want %s", code, got, want)
+		}
+	}
+}
@@ -1,29 +1,905 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"runtime"
 	"strings"
+	"time"
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	defer handlePanic()
+
+	args := os.Args[1:]
+	if len(args) == 0 {
 		printUsage()
-		os.Exit(1)
+		return
+	}
+
+	switch args[0] {
+	case "now":
+		runNowCmd(args[1:])
+	case "air":
+		runAirCmd(args[1:])
+	case "hourly":
+		runHourlyCmd(args[1:])
+	case "compare-air":
+		runCompareAirCmd(args[1:])
+	case "compare":
+		runCompareCmd(args[1:])
+	case "init":
+		runInitCmd(args[1:])
+	case "search":
+		runSearchCmd(args[1:])
+	case "cache":
+		runCacheCmd(args[1:])
+	case "profile":
+		runProfileCmd(args[1:])
+	case "doctor":
+		runDoctorCmd(args[1:])
+	case "repl":
+		runNowCmd(append(args[1:], "--stdin"))
+	case "codes": // 숨김: 개발용 WMO 코드 표
+		printCodes()
+	default:
+		runNowCmd(args)
+	}
+}
+
+// newFlagSet은 모든 명령이 공유하는 flag를 등록한다.
+// 설정 파일의 값은 mustLoadConfig가 flag 기본값으로 넣는다.
+func newFlagSet(name string, opts *Options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = printUsage
+
+	opts.Precision = newPrecision()
+	fs.StringVar(&opts.Unit, "unit", "c", "")
+	fs.StringVar(&opts.Lang, "lang", langAuto, "")
+	fs.StringVar(&opts.Lang, "locale", langAuto, "") // --lang 과 같다.
+	fs.DurationVar(&opts.Timeout, "timeout", 8*time.Second, "")
+	fs.DurationVar(&opts.GeocodeTimeout, "geocode-timeout", 0, "")
+	fs.DurationVar(&opts.ForecastTimeout, "forecast-timeout", 0, "")
+	fs.DurationVar(&opts.AirTimeout, "air-timeout", 0, "")
+	fs.DurationVar(&opts.Deadline, "deadline", 0, "")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "")
+	fs.BoolVar(&opts.BestEffort, "best-effort", false, "")
+	fs.BoolVar(&opts.Here, "here", false, "")
+	fs.StringVar(&opts.CoordsArg, "coords", "", "")
+	fs.StringVar(&opts.Label, "label", "", "")
+	fs.StringVar(&opts.Label, "location-label", "", "") // --label 과 같다.
+	fs.StringVar(&opts.AlsoTZ, "also-tz", "", "")
+	fs.StringVar(&opts.AlsoTZ, "output-timezone-list", "", "") // --also-tz 와 같다.
+	fs.StringVar(&opts.CacheDir, "cache-dir", "", "")
+	fs.BoolVar(&opts.NoCache, "no-cache", false, "")
+	fs.BoolVar(&opts.RefreshGeocode, "refresh-geocode", false, "")
+	fs.Float64Var(&opts.GridCache, "grid-cache", 0, "")
+	fs.StringVar(&opts.GeocodeProvider, "geocode-provider", geocodeProviderOpenMeteo, "")
+	fs.StringVar(&opts.GeocodeDB, "geocode-db", "", "")
+	fs.IntVar(&opts.GeocodeCount, "geocode-count", defaultGeocodeCount, "")
+	fs.BoolVar(&opts.PrivacyRound, "round-coordinates-privacy", false, "")
+	fs.Float64Var(&opts.PrivacyGrid, "privacy-grid", defaultPrivacyGrid, "")
+	fs.StringVar(&opts.GeocodeURLs, "geocode-url", "", "")
+	fs.StringVar(&opts.ForecastURLs, "forecast-url", "", "")
+	fs.StringVar(&opts.AirURLs, "air-url", "", "")
+	fs.StringVar(&opts.ArchiveURLs, "archive-url", "", "")
+	fs.StringVar(&opts.RequestID, "request-id", "", "")
+	fs.BoolVar(&opts.Verbose, "verbose", false, "")
+	fs.BoolVar(&opts.DebugErrors, "debug-errors", false, "")
+	fs.BoolVar(&opts.ConciseErrors, "concise-errors", false, "")
+	fs.StringVar(&opts.IconSet, "icon-set", iconSetAuto, "")
+	fs.StringVar(&opts.Color, "color", colorAuto, "")
+	fs.StringVar(&opts.AQIStandard, "aqi-standard", aqiStandardUS, "")
+	fs.BoolVar(&opts.ClampNegativeAQI, "clamp-negative-aqi", true, "")
+	fs.StringVar(&opts.AQIStandard, "aqi-source", aqiStandardUS, "") // --aqi-standard 와 같다.
+	fs.BoolVar(&opts.NoEmojiWidthHack, "no-emoji-width-hack", false, "")
+	fs.StringVar(&opts.ThresholdFile, "threshold-file", "", "")
+	fs.BoolVar(&opts.StrictHTTPS, "strict-https", false, "")
+	fs.Var((*stringList)(&opts.Pins), "pin", "")
+	fs.Var(opts.Precision, "fmt", "")
+	fs.Func("profile-file", "", profileFileFlag(&opts.ConfigFile))
+	return fs
+}
+
+// mustLoadConfig는 args의 --profile-file (또는 $WEATHER_CONFIG, 기본 위치) 설정을 읽어
+// fs의 unit, lang 기본값으로 넣는다. 실패하면 종료한다. fs에 명령의 flag를 모두 등록한 뒤 파싱 전에 부른다.
+func mustLoadConfig(fs *flag.FlagSet, args []string) Config {
+	file, err := scanProfileFile(fs, args)
+	if err != nil {
+		usageFail("%v", err)
+	}
+	path, explicit, err := configPath(file)
+	if err != nil {
+		return Config{}
+	}
+	cfg, err := loadConfigFile(path, explicit)
+	if err != nil {
+		fail("%v", err)
+	}
+	applyConfigDefaults(fs, cfg)
+	return cfg
+}
+
+// applyConfigDefaults는 설정의 단위/언어를 flag 기본값으로 만든다. 명령줄에서 주면 그 값이 이긴다.
+func applyConfigDefaults(fs *flag.FlagSet, cfg Config) {
+	for name, v := range map[string]string{"unit": cfg.Unit, "lang": cfg.Lang, "locale": cfg.Lang} {
+		if f := fs.Lookup(name); f != nil && v != "" {
+			_ = f.Value.Set(v) // 문자열 flag
+			f.DefValue = v
+		}
+	}
+}
+
+// validateOptions는 파싱이 끝난 공통 flag 값을 검사하고 기본값을 채운다.
+func validateOptions(opts *Options) {
+	if !validUnit(opts.Unit) {
+		fail("invalid --unit %q (c, f, auto)", opts.Unit)
+	}
+	if ls := strings.Split(opts.Lang, ","); len(ls) == 2 {
+		if !validLang(ls[0]) || !validLang(ls[1]) || ls[0] == ls[1] {
+			fail("invalid --lang %q (ko,en)", opts.Lang)
+		}
+		opts.Lang, opts.DualLang = ls[0], true
+	}
+	if opts.Lang == langAuto {
+		opts.Lang = detectLanguage(os.Getenv)
+	}
+	if !validLang(opts.Lang) {
+		fail("invalid --lang %q (auto, ko, en)", opts.Lang)
+	}
+	if opts.JSONLines {
+		opts.JSON = true
+	}
+	switch opts.GeocodeProvider {
+	case geocodeProviderOpenMeteo:
+	case geocodeProviderLocal:
+		if opts.GeocodeDB == "" {
+			fail("--geocode-provider=local requires --geocode-db <csv>")
+		}
+	default:
+		fail("invalid --geocode-provider %q (openmeteo, local)", opts.GeocodeProvider)
+	}
+	if err := validPrivacyGrid(opts.PrivacyGrid); err != nil {
+		fail("%v", err)
+	}
+	sections, err := parseSummaryOrder(opts.SummaryOrder)
+	if err != nil {
+		fail("%v", err)
+	}
+	opts.Sections = sections
+	coords, err := parseCoords(opts.CoordsArg)
+	if err != nil {
+		fail("%v", err)
+	}
+	if coords != nil && opts.Here {
+		fail("--coords and --here cannot be used together")
+	}
+	opts.Coords = coords
+	zones, err := parseZones(opts.AlsoTZ)
+	if err != nil {
+		fail("%v", err)
+	}
+	opts.Zones = zones
+	if opts.GeocodeCount < 1 || opts.GeocodeCount > maxGeocodeCount {
+		fail("invalid --geocode-count %d (1 ~ %d)", opts.GeocodeCount, maxGeocodeCount)
+	}
+	for _, e := range []struct {
+		flag string
+		val  string
+		dst  *[]string
+	}{
+		{"geocode-url", opts.GeocodeURLs, &endpoints.Geocode},
+		{"forecast-url", opts.ForecastURLs, &endpoints.Forecast},
+		{"air-url", opts.AirURLs, &endpoints.Air},
+		{"archive-url", opts.ArchiveURLs, &endpoints.Archive},
+	} {
+		urls, err := parseURLList(e.flag, e.val, *e.dst)
+		if err != nil {
+			fail("%v", err)
+		}
+		*e.dst = urls
+	}
+	endpoints.verbose = opts.Verbose
+	if opts.GridCache < 0 || opts.GridCache > 1 {
+		fail("invalid --grid-cache %v (0 ~ 1 degrees)", opts.GridCache)
+	}
+
+	switch opts.IconSet {
+	case iconSetAuto:
+		opts.IconSet = detectIconSet(os.Getenv, runtime.GOOS)
+	case iconSetEmoji, iconSetASCII:
+	default:
+		fail("invalid --icon-set %q (auto, emoji, ascii)", opts.IconSet)
+	}
+
+	if opts.AQIStandard != aqiStandardUS && opts.AQIStandard != aqiStandardEU {
+		fail("invalid --aqi-standard %q (us, eu)", opts.AQIStandard)
+	}
+	switch opts.Color {
+	case colorAuto, colorAlways, colorNever:
+		opts.UseColor = colorEnabled(opts.Color, os.Getenv, isTerminal(os.Stdout))
+	default:
+		fail("invalid --color %q (auto, always, never)", opts.Color)
+	}
+
+	if opts.ThresholdFile != "" {
+		t, err := loadThresholds(opts.ThresholdFile)
+		if err != nil {
+			fail("%v", err)
+		}
+		thresholds = t
+	}
+
+	if opts.RequestID == "" {
+		opts.RequestID = newRequestID()
+	}
+	if opts.Verbose {
+		fmt.Fprintf(os.Stderr, "debug: request id %s\n", opts.RequestID)
+	}
+}
+
+// hasLocation은 도시 이름 없이도 위치를 알 수 있는지 본다. (--here, --coords)
+func (o Options) hasLocation() bool {
+	return o.Here || o.Coords != nil
+}
+
+// rejectCoords는 여러 도시를 다루는 명령에 --coords 를 주면 종료한다. (모든 도시가 같은 좌표가 된다)
+func rejectCoords(opts Options, what string) {
+	if opts.Coords != nil {
+		usageFail("--coords cannot be used with %s", what)
+	}
+}
+
+// defaultCity는 인자 > $WEATHER_CITY > 설정 파일 순으로 도시를 고른다.
+func defaultCity(arg string, cfg Config) string {
+	if arg != "" {
+		return arg
+	}
+	if env := strings.TrimSpace(os.Getenv("WEATHER_CITY")); env != "" {
+		return env
+	}
+	return cfg.City
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+func runNowCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather", &opts)
+	fs.BoolVar(&opts.Moon, "moon", false, "")
+	fs.BoolVar(&opts.NoHeader, "no-header", false, "")
+	fs.BoolVar(&opts.A11y, "a11y", false, "")
+	fs.BoolVar(&opts.Advice, "advice", false, "")
+	fs.IntVar(&opts.AQIWarnAt, "aqi-warn-at", 0, "")
+	fs.StringVar(&opts.WindScale, "wind-scale", "speed", "")
+	fs.BoolVar(&opts.VsNormal, "vs-normal", false, "")
+	fs.BoolVar(&opts.Explain, "explain", false, "")
+	fs.BoolVar(&opts.CompactAir, "compact-air", false, "")
+	fs.BoolVar(&opts.JSON, "json", false, "")
+	fs.BoolVar(&opts.JSONLines, "json-lines", false, "")
+	fs.BoolVar(&opts.JSONCompact, "json-compact", false, "")
+	fs.BoolVar(&opts.Markdown, "markdown", false, "")
+	fs.StringVar(&opts.Format, "format", "", "")
+	formatFile := fs.String("format-file", "", "")
+	fs.StringVar(formatFile, "summary-template-file", "", "") // --format-file 과 같다.
+	fs.StringVar(&opts.FieldMissing, "field-missing", fieldMissingError, "")
+	fs.IntVar(&opts.PastHours, "past-hours", 0, "")
+	fs.BoolVar(&opts.RoundDownPrecip, "round-down-precip", false, "")
+	fs.Float64Var(&opts.PrecipStep, "precip-step", 0, "")
+	roundPrecip5 := fs.Bool("round-precip-to-5", false, "")
+	fs.StringVar(&opts.SummaryOrder, "summary-order", "", "")
+	fs.BoolVar(&opts.FeelsFirst, "feels-first", false, "")
+	fs.BoolVar(&opts.FeelsFirst, "apparent-only", false, "") // --feels-first 와 같다.
+	fs.BoolVar(&opts.HeatIndex, "relative-humidity-comfort", false, "")
+	fs.BoolVar(&opts.WindChill, "wind-chill", false, "")
+	fs.BoolVar(&opts.PM25Avg, "pm25-24h", false, "")
+	fs.BoolVar(&opts.TopPollutant, "top-pollutant", false, "")
+	fs.BoolVar(&opts.SinceSunrise, "since-sunrise", false, "")
+	fs.IntVar(&opts.PrecipWindow, "precip-window", 0, "")
+	fs.Float64Var(&opts.PrecipThreshold, "precip-threshold", defaultPrecipThreshold, "")
+	fs.BoolVar(&opts.QR, "qr", false, "")
+	fs.BoolVar(&opts.QR, "show-url-qr", false, "") // --qr 과 같다.
+	fs.IntVar(&opts.DetailLevel, "detail-level", detailDefault, "")
+	fs.Var(&opts.MinTempAlert, "min-temp-alert", "")
+	fs.Var(&opts.MaxTempAlert, "max-temp-alert", "")
+	fs.Var(&opts.IfCond, "if", "")
+	fs.StringVar(&opts.Webhook, "webhook", "", "")
+	fs.StringVar(&opts.SaveJSON, "save-json", "", "")
+	fs.StringVar(&opts.HistoryDB, "db", "", "")
+	fs.StringVar(&opts.HistoryDB, "history-append", "", "") // --db 와 같다.
+	fs.BoolVar(&opts.ExitOnWarning, "exit-on-warning", false, "")
+	fs.BoolVar(&opts.Validate, "validate", false, "")
+	profile := fs.String("profile", "", "")
+	from := fs.String("from", "", "")
+	stdin := fs.Bool("stdin", false, "")
+	fs.BoolVar(&opts.FailFastGeocode, "fail-fast-geocode", false, "")
+	fs.StringVar(&opts.Sort, "sort", "", "")
+	diff := fs.Bool("diff", false, "")
+
+	var (
+		repeat   int
+		interval time.Duration
+	)
+	fs.IntVar(&repeat, "repeat", 1, "")
+	fs.DurationVar(&interval, "interval", time.Minute, "")
+
+	cfg := mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	city := strings.Join(args, " ")
+	if *profile != "" {
+		p, ok := cfg.Profiles[*profile]
+		if !ok {
+			fail("unknown profile %q (weather profile list)", *profile)
+		}
+		city = applyProfile(p, city, &opts, setFlags(fs))
+	}
+
+	validateOptions(&opts)
+	if opts.WindScale != "speed" && opts.WindScale != "beaufort" {
+		fail("invalid --wind-scale %q (speed, beaufort)", opts.WindScale)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if opts.DetailLevel < detailOneLine || opts.DetailLevel > detailAll {
+		fail("invalid --detail-level %d (0 ~ 3)", opts.DetailLevel)
+	}
+	if opts.AQIWarnAt < 0 || (opts.AQIWarnAt == 0 && setFlags(fs)["aqi-warn-at"]) {
+		fail("invalid --aqi-warn-at %d (positive US AQI)", opts.AQIWarnAt)
+	}
+	if opts.PrecipWindow < 0 || opts.PrecipWindow > maxPrecipWindow {
+		fail("invalid --precip-window %d (0 ~ %d hours)", opts.PrecipWindow, maxPrecipWindow)
+	}
+	if opts.PrecipThreshold <= 0 || opts.PrecipThreshold > 100 {
+		fail("invalid --precip-threshold %v (1 ~ 100 %%)", opts.PrecipThreshold)
+	}
+	if *roundPrecip5 && opts.PrecipStep == 0 {
+		opts.PrecipStep = 5
+	}
+	if opts.PrecipStep < 0 || opts.PrecipStep > 50 {
+		fail("invalid --precip-step %v (0 ~ 50 %%)", opts.PrecipStep)
+	}
+	if opts.PastHours < 0 || opts.PastHours > maxPastHours {
+		fail("invalid --past-hours %d (0 ~ %d)", opts.PastHours, maxPastHours)
+	}
+	if opts.FieldMissing != fieldMissingError && opts.FieldMissing != fieldMissingEmpty {
+		fail("invalid --field-missing %q (error, empty)", opts.FieldMissing)
+	}
+	opts.FormatSource = "--format"
+	if *formatFile != "" {
+		if opts.Format != "" {
+			fmt.Fprintln(os.Stderr, "warning: --format overrides --format-file")
+		} else {
+			tmpl, err := loadFormatFile(*formatFile)
+			if err != nil {
+				fail("%v", err)
+			}
+			opts.Format, opts.FormatSource = tmpl, "--format-file "+*formatFile
+		}
+	}
+	if opts.Format != "" {
+		t, err := parseFormat(opts.Format, opts.FormatSource, opts.FieldMissing)
+		if err != nil {
+			fail("%v", err)
+		}
+		opts.Template = t
+	}
+	if opts.Sort != "" && opts.Sort != "condition" {
+		fail("invalid --sort %q (condition)", opts.Sort)
+	}
+	if *diff || *stdin || *from != "" {
+		rejectCoords(opts, "--diff, --stdin, --from")
+	}
+	if *diff {
+		if len(args) != 2 {
+			usageFail(`usage: weather now --diff <city> <city> (이름에 공백이 있으면 "new york" 처럼 따옴표)`)
+		}
+		failOnRunError(RunDiff(ctx, args[0], args[1], opts), opts)
+		return
+	}
+	if *stdin {
+		if *from != "" {
+			usageFail("--stdin and --from cannot be used together")
+		}
+		failOnRunError(RunRepl(ctx, os.Stdin, isTerminal(os.Stdin), opts), opts)
+		return
+	}
+	if *from != "" {
+		cities, err := loadCityList(*from)
+		if err != nil {
+			fail("%v", err)
+		}
+		if len(cities) == 0 {
+			usageFail("no cities in %s", *from)
+		}
+		failOnRunError(RunBatch(ctx, cities, opts), opts)
+		return
+	}
+
+	city = defaultCity(city, cfg)
+	if city == "" && !opts.hasLocation() {
+		usageFail("city required (또는 $WEATHER_CITY, weather init 으로 기본 도시 설정)")
+	}
+
+	if repeat > 1 {
+		failOnRunError(runRepeat(ctx, city, opts, repeat, interval), opts)
+		return
 	}
 
-	city := strings.Join(os.Args[1:], " ")
+	failOnRunError(RunNow(ctx, city, opts), opts)
+}
 
-	if err := RunNow(city); err != nil {
+// failOnRunError는 기온 경고면 메시지 없이 exit 3, 그 밖의 에러는 exit 1 이다.
+// 기본은 friendlyError 문구이고, --debug-errors 면 감싼 에러 전체를 보여준다.
+func failOnRunError(err error, opts Options) {
+	if errors.Is(err, errTempAlert) {
+		os.Exit(tempAlertExitCode)
+	}
+	if err == nil {
+		return
+	}
+	if opts.debugErrorsEnabled() {
 		fail("failed: %v", err)
 	}
+	if msg := friendlyError(err); msg != err.Error() {
+		fail("%s (자세히: --debug-errors)", msg)
+	}
+	fail("failed: %v", err)
+}
+
+func runAirCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather air", &opts)
+	fs.BoolVar(&opts.AirDetail, "detail", false, "")
+
+	cfg := mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	validateOptions(&opts)
+
+	city := defaultCity(strings.Join(args, " "), cfg)
+	if city == "" && !opts.hasLocation() {
+		usageFail("city required (또는 $WEATHER_CITY, weather init 으로 기본 도시 설정)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failOnRunError(RunAir(ctx, city, opts), opts)
+}
+
+func runCompareAirCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather compare-air", &opts)
+
+	mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	validateOptions(&opts)
+	rejectCoords(opts, "compare-air")
+	if len(args) != 2 {
+		usageFail(`usage: weather compare-air <city> <city> (이름에 공백이 있으면 "new york" 처럼 따옴표)`)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failOnRunError(RunCompareAir(ctx, args[0], args[1], opts), opts)
+}
+
+func runCompareCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather compare", &opts)
+	fs.StringVar(&opts.GroupBy, "group-by", "", "")
+	byGrade := fs.Bool("group-by-grade", false, "") // --group-by=grade 와 같다.
+
+	mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	validateOptions(&opts)
+	rejectCoords(opts, "compare")
+	if *byGrade {
+		opts.GroupBy = groupByGrade
+	}
+	if opts.GroupBy != "" && opts.GroupBy != groupByGrade {
+		fail("invalid --group-by %q (grade)", opts.GroupBy)
+	}
+	if len(args) < 2 || len(args) > maxCompareCities {
+		usageFail(`usage: weather compare <city> <city> [...] (2 ~ %d곳, 이름에 공백이 있으면 "new york" 처럼 따옴표)`, maxCompareCities)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failOnRunError(RunCompare(ctx, args, opts), opts)
+}
+
+func runHourlyCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather hourly", &opts)
+	hours := fs.Int("hours", defaultHourlyHours, "")
+	graph := fs.Bool("graph", false, "")
+	forceGraph := fs.Bool("force-graph", false, "")
+
+	cfg := mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	validateOptions(&opts)
+	if *hours < 1 || *hours > maxHourlyHours {
+		fail("invalid --hours %d (1 ~ %d)", *hours, maxHourlyHours)
+	}
+
+	city := defaultCity(strings.Join(args, " "), cfg)
+	if city == "" && !opts.hasLocation() {
+		usageFail("city required (또는 $WEATHER_CITY, weather init 으로 기본 도시 설정)")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failOnRunError(RunHourly(ctx, city, *hours, *graph || *forceGraph, *forceGraph, opts), opts)
+}
+
+// runDoctorCmd는 설정 파일이 깨져 있어도 보고할 수 있도록 빈 Config로 flag를 만든다.
+func runDoctorCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather doctor", &opts)
+	parseArgs(fs, args)
+	validateOptions(&opts)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !RunDoctor(ctx, opts) {
+		fail("doctor found problems")
+	}
+}
+
+func runSearchCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather search", &opts)
+	var maxResults int
+	fs.IntVar(&maxResults, "max-results", maxSearchLimit, "")
+	fs.IntVar(&maxResults, "limit", maxSearchLimit, "") // 예전 이름
+
+	mustLoadConfig(fs, args)
+	args = parseArgs(fs, args)
+	validateOptions(&opts)
+
+	query := strings.Join(args, " ")
+	if query == "" {
+		usageFail("search query required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	failOnRunError(RunSearch(ctx, query, maxResults, opts), opts)
+}
+
+func runCacheCmd(args []string) {
+	fs := flag.NewFlagSet("weather cache", flag.ExitOnError)
+	fs.Usage = printUsage
+	flagDir := fs.String("cache-dir", "", "")
+	args = parseArgs(fs, args)
+
+	if len(args) != 1 {
+		usageFail("usage: weather cache <info|clear>")
+	}
+
+	dir, err := cacheDir(*flagDir)
+	if err != nil {
+		fail("%v", err)
+	}
+	cache := diskCache{dir: dir}
+
+	switch args[0] {
+	case "info":
+		entries, size, err := cache.stats()
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("경로 %s\n", dir)
+		fmt.Printf("항목 %d개 | %s\n", entries, humanBytes(size))
+	case "clear":
+		entries, size, err := cache.clear()
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("캐시를 비웠습니다: %d개, %s (%s)\n", entries, humanBytes(size), dir)
+	default:
+		usageFail("unknown cache command %q (info, clear)", args[0])
+	}
+}
+
+// runProfileCmd는 프로필을 관리한다.
+//
+//	weather profile add <name> <city> [--unit c|f] [--lang ko|en]
+//	weather profile list
+//	weather profile remove <name>
+func runProfileCmd(args []string) {
+	var opts Options
+	fs := newFlagSet("weather profile", &opts)
+	args = parseArgs(fs, args)
+	path, _, err := configPath(opts.ConfigFile)
+	if err != nil {
+		fail("%v", err)
+	}
+	if len(args) == 0 {
+		usageFail("usage: weather profile <add|list|remove>")
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) < 3 {
+			usageFail("usage: weather profile add <name> <city> [--unit c|f] [--lang ko|en]")
+		}
+		set := setFlags(fs)
+		validateOptions(&opts)
+		rejectCoords(opts, "profile add")
+
+		p := Profile{City: strings.Join(args[2:], " ")}
+		if set["unit"] {
+			p.Unit = opts.Unit
+		}
+		if set["lang"] || set["locale"] {
+			p.Lang = opts.Lang
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		loc, err := addProfile(ctx, path, args[1], p, opts)
+		if err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("저장했습니다: %s → %s\n", args[1], placeLabel(loc))
+	case "list":
+		cfg, err := loadConfig(path)
+		if err != nil {
+			fail("%v", err)
+		}
+		printProfiles(cfg)
+	case "remove":
+		if len(args) != 2 {
+			usageFail("usage: weather profile remove <name>")
+		}
+		if err := removeProfile(path, args[1]); err != nil {
+			fail("%v", err)
+		}
+		fmt.Printf("삭제했습니다: %s\n", args[1])
+	default:
+		usageFail("unknown profile command %q (add, list, remove)", args[0])
+	}
+}
+
+// setFlags는 명령줄에서 직접 준 flag 이름을 모은다.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+func runInitCmd(args []string) {
+	fs := flag.NewFlagSet("weather init", flag.ExitOnError)
+	fs.Usage = printUsage
+	var file string
+	fs.Func("profile-file", "", profileFileFlag(&file))
+	parseArgs(fs, args)
+
+	path, _, err := configPath(file)
+	if err != nil {
+		fail("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := runInitWizard(ctx, os.Stdin, os.Stdout, path); err != nil {
+		fail("init failed: %v", err)
+	}
+}
+
+// 너무 짧은 간격은 API rate limit에 걸리기 쉽다.
+const minRepeatInterval = 10 * time.Second
+
+// runRepeat은 interval마다 RunNow를 n번 실행하고 각 샘플 앞에 시각을 붙인다.
+// ctx가 취소되면 (Ctrl-C) 남은 샘플 없이 정상 종료한다.
+func runRepeat(ctx context.Context, city string, opts Options, n int, interval time.Duration) error {
+	if interval < minRepeatInterval {
+		return fmt.Errorf("--interval must be at least %s", minRepeatInterval)
+	}
+
+	alerted := false
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				continue
+			case <-time.After(interval):
+			}
+		}
+
+		// JSON 출력에는 time 필드가 있으므로 머리줄을 넣지 않는다.
+		if !opts.JSON {
+			fmt.Printf("# %s (%d/%d)\n", nowFunc().Format(time.RFC3339), i+1, n)
+		}
+		if err := RunNow(ctx, city, opts); err != nil {
+			if errors.Is(err, errTempAlert) {
+				alerted = true
+				continue
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+	}
+	if alerted {
+		return errTempAlert
+	}
+	return nil
+}
+
+// parseArgs는 flag와 도시 이름이 섞여 있어도 모두 파싱되도록 한다.
+// (e.g. weather new york --timeout 3s)
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var rest []string
+	for {
+		_ = fs.Parse(args) // ExitOnError
+		args = fs.Args()
+		if len(args) == 0 {
+			return rest
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
 }
 
 func printUsage() {
 	fmt.Println("Usage:")
-	fmt.Println("  weather <city>")
+	fmt.Println("  weather [now] [options] <city>")
+	fmt.Println("  weather air [--detail] <city>")
+	fmt.Println("  weather hourly [--hours n] [--graph] <city>")
+	fmt.Println("  weather compare-air <city> <city>")
+	fmt.Println("  weather compare [--group-by grade] <city> <city> [...]  (표, 도시별 결과는 10분간 캐시)")
+	fmt.Println("  weather search [--max-results n] <query>")
+	fmt.Println("  weather repl [options]    (stdin에서 도시를 한 줄씩, quit 로 끝)")
+	fmt.Println("  weather init")
+	fmt.Println("  weather cache <info|clear> [--cache-dir dir]")
+	fmt.Println("  weather profile <add <name> <city>|list|remove <name>>")
+	fmt.Println("  weather doctor")
+	fmt.Println("")
+	fmt.Println("Options:")
+	fmt.Println("  --unit <c|f|auto>         온도 단위 (기본 c, auto: 위치한 나라 기준)")
+	fmt.Println("  --lang <auto|ko|en>       지역명 언어 (기본 auto: $LANG 등으로 추정, 모르면 ko, --locale 도 같음)")
+	fmt.Println("  --lang ko,en              라벨을 한국어와 영어로 함께 표시 (지역명은 첫 언어)")
+	fmt.Println("  --timeout <dur>           전체 요청 타임아웃 (기본 8s)")
+	fmt.Println("  --geocode-timeout <dur>   지오코딩 타임아웃 (기본 --timeout)")
+	fmt.Println("  --forecast-timeout <dur>  날씨 타임아웃 (기본 --timeout)")
+	fmt.Println("  --air-timeout <dur>       대기질 타임아웃 (기본 --timeout)")
+	fmt.Println("  --deadline <dur>          조회 한 번 전체의 제한 시간 (예: 15s, --best-effort 면 받은 것만 출력)")
+	fmt.Println("  --dry-run                 호출할 URL만 출력하고 종료")
+	fmt.Println("  --best-effort             일부 호출이 실패해도 가져온 정보만 출력")
+	fmt.Println("  --here                    도시 대신 IP 기반 추정 위치 사용")
+	fmt.Println("  --coords <lat,lon>        지오코딩 없이 좌표로 조회 (예: 37.5665,126.978, 이름은 --label)")
+	fmt.Println("  --label <name>            출력에 보일 위치 이름 (좌표는 그대로, 예: --label=\"우리 집\", --location-label 도 같음)")
+	fmt.Println("  --also-tz <zones>         헤더에 다른 시간대 시각도 표시 (예: America/New_York,Europe/London)")
+	fmt.Println("  --strict-https            TLS 1.2 이상만 허용")
+	fmt.Println("  --pin <sha256>            Open-Meteo 인증서 SHA-256 지문 고정 (반복 가능)")
+	fmt.Println("  --cache-dir <dir>         캐시 위치 (기본 $WEATHER_CACHE_DIR 또는 사용자 캐시 폴더)")
+	fmt.Println("  --no-cache                캐시를 사용하지 않음")
+	fmt.Println("  --refresh-geocode         지오코딩 캐시를 무시하고 새로 조회 (결과는 다시 캐시)")
+	fmt.Println("  --geocode-provider <p>    openmeteo (기본), local (--geocode-db CSV, 오프라인)")
+	fmt.Println("  --geocode-db <csv>        local 지오코더 CSV (name,latitude,longitude[,country,country_code,admin1,population])")
+	fmt.Println("  --round-coordinates-privacy")
+	fmt.Println("                            API에 보내는 좌표를 --privacy-grid 간격으로 반올림 (--here 등)")
+	fmt.Println("  --privacy-grid <deg>      좌표 반올림 간격 (기본 0.1, 약 11km)")
+	fmt.Println("  --geocode-count <n>       지오코딩 후보 수 (기본 1: 첫 결과, 늘리면 인구순으로 고르고 동명 지역 경고, 1 ~ 100)")
+	fmt.Println("  --forecast-url <urls>     날씨 API base URL 목록 (쉼표 구분, 앞의 것이 실패하면 다음 것)")
+	fmt.Println("  --air-url <urls>          대기질 API base URL 목록 (--geocode-url, --archive-url 도 같음)")
+	fmt.Println("  --grid-cache <deg>        좌표를 deg 간격으로 반올림해 근처 위치와 날씨/대기질 공유 (예: 0.1, 10분)")
+	fmt.Println("  --request-id <id>         모든 요청의 X-Request-ID (기본: 실행마다 랜덤 UUID)")
+	fmt.Println("  --verbose                 요청 URL 등 디버그 정보를 stderr에 출력")
+	fmt.Println("  --debug-errors            실패 시 짧은 안내 대신 에러 전체를 출력 ($WEATHER_DEBUG 도 같음)")
+	fmt.Println("  --concise-errors          $WEATHER_DEBUG 가 있어도 짧은 안내만 출력 (기본)")
+	fmt.Println("  --icon-set <s>            auto (기본, $LANG 등으로 추정), emoji, ascii")
+	fmt.Println("  --aqi-standard <s>        대기질 지수: us (기본, US AQI), eu (European AQI, EEA 6단계)")
+	fmt.Println("  --clamp-negative-aqi      음수 AQI/PM 값을 0으로 보정 (기본 켜짐, 원값은 JSON raw_negative, =false 로 끔)")
+	fmt.Println("  --color <when>            대기질 등급 색: auto (기본, 터미널이고 $NO_COLOR 없을 때), always, never")
+	fmt.Println("  --no-emoji-width-hack     이모지 뒤 여백을 한 칸만 (이모지를 2칸으로 그리는 터미널)")
+	fmt.Println("  --threshold-file <path>   PM10/PM2.5/AQI 등급 기준 JSON (pm10, pm2_5, us_aqi, condition_labels: WMO 코드별 날씨 라벨)")
+	fmt.Println("  --moon                    달 위상 표시")
+	fmt.Println("  --fmt <field=n>           필드별 소수점 자릿수 (반복 가능, temp/precip/rain/pm10/pm25/visibility/wind/humidity/uv)")
+	fmt.Println("  --no-header               도시/시각 줄 생략")
+	fmt.Println("  --precip-window <n>       앞으로 n시간 중 비가 올 것 같은 첫 시각 (예: 14시경 비 예상 (70%))")
+	fmt.Println("  --precip-threshold <p>    --precip-window 의 비 예상 기준 강수 확률 (기본 50%)")
+	fmt.Println("  --since-sunrise           낮 시간 경과율과 일몰(밤이면 일출)까지 남은 시간")
+	fmt.Println("  --pm25-24h                초미세먼지 최근 24시간 평균 함께 표시 (건강 기준은 24시간 평균)")
+	fmt.Println("  --top-pollutant           등급이 가장 나쁜 오염물질 표시 (예: 주요 오염물질: 초미세먼지(PM2.5))")
+	fmt.Println("  --summary-order <list>    섹션 출력 순서 (예: aqi,temp,wind, 나머지는 기본 순서로 뒤에)")
+	fmt.Println("                            temp, rain, wind, extra, normal, aqi, sun, moon, advice")
+	fmt.Println("  --feels-first             체감 온도와 쾌적도(추움~무더움)를 앞에, 실제 기온은 괄호에 (--apparent-only 도 같음)")
+	fmt.Println("  --relative-humidity-comfort")
+	fmt.Println("                            덥고 습할 때 (27°C, 습도 40% 이상만) 습도를 반영한 열지수 표시")
+	fmt.Println("  --wind-chill              춥고 바람 불 때 (10°C 이하, 풍속 4.8km/h 초과만) 풍속냉각 체감 온도 표시")
+	fmt.Println("  --round-down-precip       강수 확률을 내림해 표시 (99.6% → 99%), 구간(낮음~매우 높음)과 원값 함께")
+	fmt.Println("  --round-precip-to-5       강수 확률을 5% 단위로 표시 (63% → 65%, JSON은 원값)")
+	fmt.Println("  --precip-step <n>         강수 확률 표시 간격을 n% 로 (예: 10)")
+	fmt.Println("  --past-hours <n>          기온 줄에 n시간 전 기온 함께 표시 (예: 3시간 전 8°C → 현재 12°C)")
+	fmt.Println("  --detail-level <n>        0: 한 줄, 1: 기본, 2: +습도/자외선/최저·최고, 3: +가스/일출·일몰/달")
+	fmt.Println("  --a11y                    이모지 없는 스크린 리더용 문장 출력")
+	fmt.Println("  --advice                  옷차림/우산/마스크 조언 표시")
+	fmt.Println("  --aqi-warn-at <n>         마스크 조언을 US AQI n 이상일 때만 (기본: AQI 100 초과 또는 PM 나쁨)")
+	fmt.Println("  --wind-scale <s>          바람 표시 방식: speed (기본), beaufort")
+	fmt.Println("  --vs-normal               최근 5년 같은 날 같은 시각 평균과 비교 (근사치)")
+	fmt.Println("  --explain                 등급 판정 기준 구간 표시")
+	fmt.Println("  --compact-air             대기질을 한 줄로 표시")
+	fmt.Println("  --json                    JSON으로 출력")
+	fmt.Println("  --json-lines              결과마다 JSON 한 줄씩 바로 출력 (--repeat 용, --json 포함)")
+	fmt.Println("  --json-compact            --json 출력을 들여쓰기 없이 한 줄로 (로그용)")
+	fmt.Println("  --min-temp-alert <t>      현재/오늘 최저가 t 이하면 서리 주의 출력, exit 3 (--unit 단위)")
+	fmt.Println("  --max-temp-alert <t>      현재/오늘 최고가 t 이상이면 폭염 주의 출력, exit 3 (--unit 단위, f 면 °F)")
+	fmt.Println("  --if <cond>               조건이 맞으면 경고 출력, exit 3 (temp, feels / > >= < <=, 예: 'temp>30', --unit 단위)")
+	fmt.Println("  --markdown                Markdown 표로 출력 (이슈/노트 붙여 넣기용)")
+	fmt.Println("  --format <tmpl>           Go 템플릿으로 한 줄 출력 (JSON 키, 예: '{{.city}} {{.weather.temperature}}')")
+	fmt.Println("  --format-file <file>      --format 템플릿을 파일에서 읽음 (둘 다 주면 --format 우선)")
+	fmt.Println("  --field-missing <m>       --format 에서 결과에 없는 필드: error (기본), empty (빈 칸)")
+	fmt.Println("  --qr                      위치 지도 URL을 QR 코드로 표시 (터미널일 때만)")
+	fmt.Println("  --webhook <url>           조회 결과 JSON을 POST (실패는 경고만)")
+	fmt.Println("  --save-json <dir>         조회마다 JSON을 <dir>/<city>-<시각>.json 으로 저장")
+	fmt.Println("  --db <file>               조회마다 SQLite 파일의 reports 테이블에 추가 (--history-append 도 같음)")
+	fmt.Println("  --exit-on-warning         webhook, save-json, --validate 경고 등 부가 작업 실패 시 에러로 종료")
+	fmt.Println("  --validate                믿기 어려운 값 (기온 -999, 음수 AQI, 범위 밖 좌표 등)을 stderr에 경고")
+	fmt.Println("  --profile <name>          저장한 프로필의 도시/단위/언어 사용")
+	fmt.Println("  --profile-file <path>     설정 파일 경로 (기본 위치 대신, $WEATHER_CONFIG 도 같음, 없으면 에러)")
+	fmt.Println("  --diff <city> <city>      두 도시의 기온/체감/대기질 차이를 한 줄로 (예: 서울이 제주보다 5.2°C 더 춥고)")
+	fmt.Println("  --from <file>             파일(- 이면 stdin)의 도시를 한 줄에 하나씩 차례로 조회")
+	fmt.Println("  --stdin                   stdin에서 도시를 한 줄씩 읽어 바로 조회 (빈 줄 무시, quit 로 끝, weather repl 도 같음)")
+	fmt.Println("  --sort condition          --from 결과를 맑음 → 뇌우 순으로 정렬해 출력")
+	fmt.Println("  --fail-fast-geocode       --from 에서 첫 실패 시 멈춤 (기본: 나머지 계속, 실패 있으면 exit 1)")
+	fmt.Println("  --repeat <n>              n번 반복 조회 (타임스탬프와 함께 출력)")
+	fmt.Println("  --interval <dur>          --repeat 간격 (기본 1m, 최소 10s)")
+	fmt.Println("")
+	fmt.Println("Commands:")
+	fmt.Println("  air                       대기질만 조회")
+	fmt.Println("  compare-air               두 도시 중 공기가 더 좋은 곳을 한 줄로 (AQI, PM2.5)")
+	fmt.Println("  hourly                    앞으로 n시간 기온 (기본 24시간)")
+	fmt.Println("  search                    지오코딩 결과를 인구순으로 표시 (--max-results, 최대 10개)")
+	fmt.Println("  init                      기본 도시/단위/언어 설정 파일 만들기")
+	fmt.Println("  cache                     캐시 경로/크기 보기 (info), 비우기 (clear)")
+	fmt.Println("  doctor                    설정/캐시/터미널 정보와 API 연결 점검 (문제 있으면 exit 1)")
+	fmt.Println("  profile                   자주 보는 위치 저장 (add), 목록 (list), 삭제 (remove)")
+	fmt.Println("")
+	fmt.Println("Air options:")
+	fmt.Println("  --detail                  오염물질별 수치/등급을 나쁜 순으로 표시")
+	fmt.Println("")
+	fmt.Println("Hourly options:")
+	fmt.Println("  --hours <n>               몇 시간 앞까지 (기본 24, 최대 48)")
+	fmt.Println("  --graph                   기온 차트로 표시 (터미널일 때만, 아니면 목록)")
+	fmt.Println("  --force-graph             파이프로 보낼 때도 차트로 표시")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  weather seoul")
 	fmt.Println(`  weather "new york"`)
-}
\ No newline at end of file
+	fmt.Println("  weather ICN")
+	fmt.Println("  weather seoul --air-timeout 3s")
+	fmt.Println("  weather seoul --repeat 6 --interval 10m")
+	fmt.Println("  weather air --detail seoul")
+	fmt.Println("  weather hourly seoul --graph")
+	fmt.Println("  weather compare-air seoul busan")
+	fmt.Println("  weather search london --max-results 5")
+	fmt.Println("  weather --here")
+	fmt.Println("  weather --coords 37.5665,126.978 --dry-run")
+	fmt.Println("  weather profile add home seoul --unit c")
+	fmt.Println("  weather now --profile home")
+	fmt.Println("")
+	fmt.Println("<city>를 생략하면 $WEATHER_CITY, 설정 파일의 기본 도시 순으로 사용합니다.")
+	fmt.Println("<city>에 대문자 공항 코드(IATA 3자, ICAO 4자)를 주면 주요 공항 목록에서 먼저 찾습니다.")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---------- Markdown output ----------
+// markdownOutputter는 GitHub 이슈나 노트에 붙여 넣기 좋은 표로 출력한다.
+//
+//	## 서울, 대한민국
+//
+//	_10-14 15:04 (KST)_
+//
+//	| 항목 | 값 |
+//	| --- | --- |
+//	| 날씨 | ☀️ 맑음 |
+//	...
+type markdownOutputter struct {
+	opts Options
+}
+
+func (o markdownOutputter) Output(r Report) error {
+	fmt.Print(markdownSummary(r, o.opts))
+	return nil
+}
+
+func markdownSummary(r Report, opts Options) string {
+	var b strings.Builder
+	p := opts.Precision
+
+	if !opts.NoHeader {
+		title := r.Location.Name
+		if r.Location.Country != "" {
+			title += ", " + r.Location.Country
+		}
+		if r.Location.Approximate {
+			title += " (" + opts.labelInline("approximate") + ")"
+		}
+		fmt.Fprintf(&b, "## %s\n\n_%s (KST)_\n\n", mdEscape(title), r.Time.Format("01-02 15:04"))
+	}
+
+	fmt.Fprintf(&b, "| %s | %s |\n| --- | --- |\n", opts.label("item"), opts.label("value"))
+	row := func(k, v string) {
+		fmt.Fprintf(&b, "| %s | %s |\n", mdEscape(k), mdEscape(v))
+	}
+
+	if r.WeatherErr == nil {
+		w := r.Weather
+		unit := tempSymbol(opts)
+		row(opts.label("weather"), opts.dual(conditionText(w.WeatherCode, w.isNight(r.Time), opts), conditionEN(w.WeatherCode)))
+		row(opts.label("temp"), fmt.Sprintf("%s%s%s (%s %s%s)",
+			p.format("temp", w.Temperature2m), unit, trendText(w, opts),
+			opts.labelInline("feels"), p.format("temp", w.ApparentTemperature), unit))
+		if w.PrecipProbability != nil {
+			row(opts.label("precip_prob"), p.format("precip", opts.bucketPrecip(*w.PrecipProbability))+"%")
+		}
+		if w.Visibility != nil {
+			km := metersToKm(*w.Visibility)
+			if opts.imperial() {
+				row(opts.label("visibility"), fmt.Sprintf("%smi (%s)", p.format("visibility", metersToMiles(*w.Visibility)), visibilityGradeKR(km)))
+			} else {
+				row(opts.label("visibility"), fmt.Sprintf("%skm (%s)", p.format("visibility", km), visibilityGradeKR(km)))
+			}
+		}
+		row(opts.label("wind"), windText(w, opts))
+	} else {
+		row(opts.label("weather"), opts.label("no_data"))
+	}
+
+	if r.AirErr == nil {
+		aq := r.Air
+		aqi, estimated := aq.usAQI()
+		aqiVal := fmt.Sprintf("AQI %d", aqi)
+		if estimated {
+			aqiVal = fmt.Sprintf("AQI ~%d, %s", aqi, opts.labelInline("pm25_based"))
+		}
+		pm10, _, _ := pm10GradeKR(aq.PM10)
+		pm25, _, _ := pm25GradeKR(aq.PM25)
+		row(opts.label("air"), fmt.Sprintf("%s (%s)", opts.dual(aqiText(aqi, opts), aqiStatusEN(aqi)), aqiVal))
+		row(opts.label("pm10"), fmt.Sprintf("%s ㎍/m³ %s", p.format("pm10", aq.PM10), opts.dual(pm10, gradeEN(pm10))))
+		row(opts.label("pm25"), fmt.Sprintf("%s ㎍/m³ %s", p.format("pm25", aq.PM25), opts.dual(pm25, gradeEN(pm25))))
+	} else {
+		row(opts.label("air"), opts.label("no_data"))
+	}
+
+	if opts.Moon {
+		row(opts.label("moon"), moonText(r.Time, opts))
+	}
+	return b.String()
+}
+
+// mdEscape는 표 칸 안에서 깨지지 않도록 |와 줄바꿈을 처리하고 연속 공백을 줄인다.
+func mdEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
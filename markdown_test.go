@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownSummary(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	r := testReport()
+	r.Location.Approximate = true
+
+	got := markdownSummary(r, testOptions())
+	want := `## 서울, 대한민국 (IP 기반 추정 위치)
+
+_03-14 15:30 (KST)_
+
+| 항목 | 값 |
+| --- | --- |
+| 날씨 | ☀️ 맑음 |
+| 기온 | 12.3°C (체감 11.0°C) |
+| 강수 확률 | 20% |
+| 가시거리 | 24.0km (좋음) |
+| 바람 | 9.4km/h |
+| 대기질 | 보통 🙂 (AQI 72) |
+| 미세먼지(PM10) | 45.0 ㎍/m³ 보통 |
+| 초미세먼지(PM2.5) | 22.0 ㎍/m³ 보통 |
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// --lang=ko,en 이면 라벨과 등급을 모두 함께 쓴다. (한국어만 남는 칸이 없어야 한다)
+func TestMarkdownSummaryDualLang(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	opts := testOptions()
+	opts.DualLang = true
+	r := testReport()
+	r.Location.Approximate = true
+	r.Air.AQIUS = nil // PM2.5 기준 추정
+
+	got := markdownSummary(r, opts)
+	for _, want := range []string{
+		"(IP 기반 추정 위치/approximate, IP based)",
+		"| 항목 (item) | 값 (value) |",
+		"| 날씨 (weather) | ☀️ 맑음 (Clear) |",
+		"| 강수 확률 (precipitation chance) | 20% |",
+		"PM2.5 기준 추정/estimated from PM2.5",
+		"| 미세먼지(PM10) (fine dust) | 45.0 ㎍/m³ 보통 (moderate) |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in:\n%s", want, got)
+		}
+	}
+
+	r.WeatherErr, r.AirErr = errTempAlert, errTempAlert
+	got = markdownSummary(r, opts)
+	if !strings.Contains(got, "| 날씨 (weather) | 정보 없음 (no data) |") || !strings.Contains(got, "| 대기질 (air quality) | 정보 없음 (no data) |") {
+		t.Errorf("no-data rows not localized:\n%s", got)
+	}
+}
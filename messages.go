@@ -0,0 +1,88 @@
+package main
+
+// ---------- Messages ----------
+// 요약 출력의 라벨 문구 (한국어, 영어). --lang=ko,en 이면 "강수 (Precip)" 처럼 함께 쓴다.
+var messages = map[string][2]string{
+	"city":        {"도시", "city"},
+	"weather":     {"날씨", "weather"},
+	"temp":        {"기온", "temperature"},
+	"feels":       {"체감", "feels like"},
+	"actual":      {"실제", "actual"},
+	"precip":      {"강수", "precip"},
+	"precip_prob": {"강수 확률", "precipitation chance"},
+	"no_prob":     {"확률 미제공", "no probability"},
+	"raw":         {"원값", "raw"},
+	"humidity":    {"습도", "humidity"},
+	"uv":          {"자외선", "UV"},
+	"today":       {"오늘", "today"},
+	"visibility":  {"가시거리", "visibility"},
+	"wind":        {"바람", "wind"},
+	"air":         {"대기질", "air quality"},
+	"pm10":        {"미세먼지(PM10)", "fine dust"},
+	"pm25":        {"초미세먼지(PM2.5)", "ultrafine dust"},
+	"no_weather":  {"날씨 정보 없음", "no weather data"},
+	"no_air":      {"대기질 정보 없음", "no air quality data"},
+	"approximate": {"IP 기반 추정 위치", "approximate, IP based"},
+	"rounded":     {"좌표 %v° 단위로 낮춤", "coordinates rounded to %v°"},
+	"query":       {"검색어", "query"},
+	"estimated":   {"추정", "estimated"},
+	"pm25_based":  {"PM2.5 기준 추정", "estimated from PM2.5"},
+	"moon":        {"달 위상", "moon phase"},
+	"no_data":     {"정보 없음", "no data"},
+	"item":        {"항목", "item"},
+	"value":       {"값", "value"},
+	"cached":      {"캐시", "cached"},
+}
+
+// msg는 key의 한국어, 영어 문구를 돌려준다. 없는 key는 key 그대로다.
+func msg(key string) (ko, en string) {
+	m, ok := messages[key]
+	if !ok {
+		return key, key
+	}
+	return m[0], m[1]
+}
+
+// label은 현재 언어 설정으로 라벨을 만든다. 기본은 한국어만이다.
+func (o Options) label(key string) string {
+	ko, en := msg(key)
+	return o.dual(ko, en)
+}
+
+// labelInline은 이미 괄호 안에 들어가는 라벨용이다. ("체감/feels like")
+func (o Options) labelInline(key string) string {
+	ko, en := msg(key)
+	if !o.DualLang {
+		return ko
+	}
+	return ko + "/" + en
+}
+
+// dual은 --lang=ko,en 일 때만 "한국어 (English)"로 합친다.
+func (o Options) dual(ko, en string) string {
+	if !o.DualLang || en == "" {
+		return ko
+	}
+	return ko + " (" + en + ")"
+}
+
+// localized는 --lang 을 따르는 문구다. en 이면 영어만, --lang=ko,en 이면 함께, 그 밖에는 한국어다.
+func (o Options) localized(ko, en string) string {
+	if !o.DualLang && o.lang() == "en" {
+		return en
+	}
+	return o.dual(ko, en)
+}
+
+// localizedInline은 이미 괄호 안에 들어가는 localized다. ("캐시/cached")
+func (o Options) localizedInline(ko, en string) string {
+	if o.DualLang {
+		return ko + "/" + en
+	}
+	return o.localized(ko, en)
+}
+
+// text는 key의 문구를 localized로 고른다.
+func (o Options) text(key string) string {
+	return o.localized(msg(key))
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ---------- Response metadata ----------
+// responseMeta는 API 응답의 Date, X-* 헤더를 엔드포인트별로 모은다. (모델/버전 변경 추적, 지원 문의용)
+// --verbose 면 응답마다 stderr에 쓰고, --json 에서는 _meta 로 내보낸다.
+// 클라이언트는 여러 도시가 같이 쓰므로 (--from, repl, compare) 기록은 조회 한 번의 ctx에 매단다. (withResponseMeta)
+type responseMeta struct {
+	mu      sync.Mutex
+	entries map[string]apiMeta // 키는 host+path, 같은 엔드포인트는 마지막 응답만 남긴다.
+}
+
+type apiMeta struct {
+	Endpoint string            `json:"endpoint"` // "api.open-meteo.com/v1/forecast"
+	Date     string            `json:"date,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"` // X-* 헤더
+}
+
+// metaKey는 ctx에 responseMeta를 매다는 키다.
+type metaKey struct{}
+
+// withResponseMeta는 이 ctx로 나가는 요청의 응답만 모으는 새 기록을 단다.
+func withResponseMeta(ctx context.Context) (context.Context, *responseMeta) {
+	m := &responseMeta{}
+	return context.WithValue(ctx, metaKey{}, m), m
+}
+
+// responseMetaFrom은 ctx에 달린 기록이다. 없으면 nil (기록하지 않는다)
+func responseMetaFrom(ctx context.Context) *responseMeta {
+	m, _ := ctx.Value(metaKey{}).(*responseMeta)
+	return m
+}
+
+// newAPIMeta는 응답 하나의 Date, X-* 헤더다.
+func newAPIMeta(resp *http.Response) apiMeta {
+	e := apiMeta{
+		Endpoint: resp.Request.URL.Host + resp.Request.URL.Path,
+		Date:     resp.Header.Get("Date"),
+	}
+	for k, v := range resp.Header {
+		if strings.HasPrefix(k, "X-") && len(v) > 0 {
+			if e.Headers == nil {
+				e.Headers = map[string]string{}
+			}
+			e.Headers[k] = strings.Join(v, ", ")
+		}
+	}
+	return e
+}
+
+// record는 e를 남긴다. m이 nil이면 아무 것도 하지 않는다.
+func (m *responseMeta) record(e apiMeta) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = map[string]apiMeta{}
+	}
+	m.entries[e.Endpoint] = e
+}
+
+// headerText는 --verbose 용 " Date=... X-Foo=..." 다.
+func (e apiMeta) headerText() string {
+	var b strings.Builder
+	if e.Date != "" {
+		b.WriteString(" Date=" + e.Date)
+	}
+	keys := make([]string, 0, len(e.Headers))
+	for k := range e.Headers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		b.WriteString(" " + k + "=" + e.Headers[k])
+	}
+	return b.String()
+}
+
+// snapshot은 지금까지 모은 응답을 엔드포인트 순으로 돌려준다. (캐시에서 읽은 호출은 없다)
+func (m *responseMeta) snapshot() []apiMeta {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]apiMeta, 0, len(m.entries))
+	for _, e := range m.entries {
+		out = append(out, e)
+	}
+	slices.SortFunc(out, func(a, b apiMeta) int { return strings.Compare(a.Endpoint, b.Endpoint) })
+	return out
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// 한 클라이언트로 여러 도시를 동시에 받아도 Report.Meta 는 자기 응답만 담는다.
+func TestReportMetaPerCity(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	srv := fakeOpenMeteo(t, map[string]GeoResult{
+		"서울": {Name: "서울", Latitude: 37.5, Longitude: 127},
+		"부산": {Name: "부산", Latitude: 35.1, Longitude: 129},
+	})
+	inner := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Fri, 14 Mar 2025 06:30:00 GMT")
+		w.Header().Set("X-Lat", r.URL.Query().Get("latitude"))
+		w.Header().Set("X-Model", "best_match")
+		inner.ServeHTTP(w, r)
+	})
+
+	opts := testOptions()
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cities := map[string]string{"서울": "37.5", "부산": "35.1"}
+	var wg sync.WaitGroup
+	for city, lat := range cities {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, _, err := fetchReport(context.Background(), client, city, opts)
+			if err != nil {
+				t.Errorf("%s: %v", city, err)
+				return
+			}
+
+			var endpoints []string
+			for _, m := range r.Meta {
+				endpoints = append(endpoints, m.Endpoint)
+				if m.Date != "Fri, 14 Mar 2025 06:30:00 GMT" || m.Headers["X-Model"] != "best_match" {
+					t.Errorf("%s: meta %+v missing Date/X-Model", city, m)
+				}
+				if want := lat; m.Headers["X-Lat"] != "" && m.Headers["X-Lat"] != want {
+					t.Errorf("%s: %s has X-Lat %s, want %s", city, m.Endpoint, m.Headers["X-Lat"], want)
+				}
+			}
+			if len(r.Meta) != 3 { // search, forecast, air-quality
+				t.Errorf("%s: meta endpoints = %v, want 3", city, endpoints)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// --coords 조회는 지오코딩을 하지 않으므로, 앞 도시의 search 응답이 남으면 안 된다.
+func TestReportMetaNotCarriedOver(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	fakeOpenMeteo(t, map[string]GeoResult{"서울": {Name: "서울", Latitude: 37.5, Longitude: 127}})
+
+	opts := testOptions()
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := fetchReport(context.Background(), client, "서울", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	opts.Coords = &GeoResult{Name: "35.1,129", Latitude: 35.1, Longitude: 129}
+	r, _, err := fetchReport(context.Background(), client, "", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Meta) != 2 {
+		t.Errorf("meta = %+v, want only forecast and air-quality", r.Meta)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// ---------- Mirrors ----------
+// 엔드포인트마다 base URL 목록을 둔다. 앞의 호스트가 실패하면 (연결 실패, 5xx) 다음 것으로 넘어간다.
+// --forecast-url a,b 처럼 쉼표로 준다. validateOptions에서 채운다.
+type endpointURLs struct {
+	Geocode  []string
+	Forecast []string
+	Air      []string
+	Archive  []string
+
+	// verbose면 응답한 호스트를 stderr에 남긴다.
+	verbose bool
+}
+
+var endpoints = endpointURLs{
+	Geocode:  []string{geocodeBaseURL},
+	Forecast: []string{forecastBaseURL},
+	Air:      []string{airBaseURL},
+	Archive:  []string{archiveBaseURL},
+}
+
+// parseURLList는 쉼표로 나눈 base URL 목록을 검사한다. 비어 있으면 def다.
+func parseURLList(flagName, s string, def []string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return def, nil
+	}
+	var urls []string
+	for _, u := range strings.Split(s, ",") {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		pu, err := url.Parse(u)
+		if err != nil || (pu.Scheme != "https" && pu.Scheme != "http") || pu.Host == "" {
+			return nil, fmt.Errorf("invalid --%s entry %q (http(s) base URL)", flagName, u)
+		}
+		urls = append(urls, u)
+	}
+	if len(urls) == 0 {
+		return def, nil
+	}
+	return urls, nil
+}
+
+// mirrorURLs는 base 목록마다 build로 요청 URL을 만든다.
+func mirrorURLs(bases []string, build func(base string) string) []string {
+	urls := make([]string, len(bases))
+	for i, b := range bases {
+		urls[i] = build(b)
+	}
+	return urls
+}
+
+// fetchWithFallback은 urls를 차례로 GET한다. 미러마다 한 번씩 retry로 시도한다.
+// 연결 실패나 5xx면 다음 URL을 시도하고, 그 밖의 응답(2xx, 4xx)은 그대로 돌려준다.
+// 모두 실패하면 마지막 5xx 응답 또는 마지막 에러를 돌려준다.
+func fetchWithFallback(ctx context.Context, client *http.Client, urls []string) (*http.Response, error) {
+	resp, err := retry(ctx, len(urls), func(i int) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, urls[i], nil)
+		if err != nil {
+			return nil, err
+		}
+		return client.Do(req)
+	}, func(i int, err error) {
+		if endpoints.verbose {
+			fmt.Fprintf(os.Stderr, "debug: %s failed (%v), trying next mirror\n", hostOf(urls[i]), err)
+		}
+	})
+	if err == nil && endpoints.verbose && len(urls) > 1 {
+		fmt.Fprintf(os.Stderr, "debug: served by %s\n", resp.Request.URL.Host)
+	}
+	return resp, err
+}
+
+func hostOf(u string) string {
+	if pu, err := url.Parse(u); err == nil {
+		return pu.Host
+	}
+	return u
+}
+
+// ---------- Retry ----------
+// retry는 attempt(0), attempt(1), ... 을 최대 n번 부른다.
+// 연결 실패나 5xx면 onRetry(i, err)를 부르고 다음 시도로 넘어간다. 그 밖의 결과는 바로 돌려준다.
+// 마지막 시도의 결과는 5xx여도 그대로 돌려준다. (호출하는 쪽이 statusError로 바꾼다)
+// ctx가 끝났으면 더 시도하지 않는다.
+func retry(ctx context.Context, n int, attempt func(i int) (*http.Response, error), onRetry func(i int, err error)) (*http.Response, error) {
+	var lastErr error
+	for i := 0; i < n; i++ {
+		resp, err := attempt(i)
+		last := i == n-1
+		switch {
+		case err != nil:
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, err // 전체 타임아웃이면 다시 해도 소용없다.
+			}
+		case resp.StatusCode >= 500 && !last:
+			resp.Body.Close()
+			lastErr = fmt.Errorf("bad status: %s", resp.Status)
+		default:
+			return resp, nil
+		}
+		if !last && onRetry != nil {
+			onRetry(i, lastErr)
+		}
+	}
+	return nil, lastErr
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// 첫 호스트가 500이면 두 번째 호스트의 응답으로 날씨를 만든다.
+func TestFetchFallsBackAfter500(t *testing.T) {
+	var primaryHits int
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryHits++
+		http.Error(w, "degraded", http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+	srv := fakeOpenMeteo(t, nil)
+	endpoints.Forecast = []string{primary.URL + "/v1/forecast", srv.URL + "/v1/forecast"}
+
+	w, err := fetchCurrentWeather(context.Background(), srv.Client(), 37.566, 126.9784, queryOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if primaryHits != 1 {
+		t.Errorf("primary hits = %d, want 1", primaryHits)
+	}
+	if w.Temperature2m != 12.3 {
+		t.Errorf("temperature = %v, want 12.3 from the second host", w.Temperature2m)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	status := func(code int) func() (*http.Response, error) {
+		return func() (*http.Response, error) {
+			return &http.Response{StatusCode: code, Status: http.StatusText(code), Body: io.NopCloser(nil)}, nil
+		}
+	}
+	failed := func() (*http.Response, error) { return nil, io.ErrUnexpectedEOF }
+
+	cases := []struct {
+		name     string
+		attempts []func() (*http.Response, error)
+		calls    int
+		status   int // 0이면 에러를 기대한다.
+	}{
+		{"first ok", []func() (*http.Response, error){status(200), status(200)}, 1, 200},
+		{"500 then ok", []func() (*http.Response, error){status(500), status(200)}, 2, 200},
+		{"conn error then ok", []func() (*http.Response, error){failed, status(200)}, 2, 200},
+		{"4xx is final", []func() (*http.Response, error){status(404), status(200)}, 1, 404},
+		{"last 5xx returned", []func() (*http.Response, error){status(500), status(503)}, 2, 503},
+		{"all conn errors", []func() (*http.Response, error){failed, failed}, 2, 0},
+	}
+	for _, c := range cases {
+		var calls, retries int
+		resp, err := retry(context.Background(), len(c.attempts), func(i int) (*http.Response, error) {
+			calls++
+			return c.attempts[i]()
+		}, func(int, error) { retries++ })
+		if calls != c.calls || retries != c.calls-1 {
+			t.Errorf("%s: calls=%d retries=%d, want %d/%d", c.name, calls, retries, c.calls, c.calls-1)
+		}
+		switch {
+		case c.status == 0 && err == nil:
+			t.Errorf("%s: expected error", c.name)
+		case c.status != 0 && (err != nil || resp.StatusCode != c.status):
+			t.Errorf("%s: got %v %v, want status %d", c.name, resp, err, c.status)
+		}
+	}
+}
+
+// ctx가 끝나면 남은 호스트를 시도하지 않는다.
+func TestRetryStopsOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	var calls int
+	_, err := retry(ctx, 3, func(int) (*http.Response, error) {
+		calls++
+		return nil, ctx.Err()
+	}, nil)
+	if err == nil || calls != 1 {
+		t.Errorf("calls=%d err=%v, want 1 call and an error", calls, err)
+	}
+}
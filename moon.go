@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ---------- Moon phase ----------
+// 기준 삭(new moon): 2000-01-06 18:14 UTC, 삭망월 29.530588853일
+const synodicMonth = 29.530588853
+
+var knownNewMoon = time.Date(2000, 1, 6, 18, 14, 0, 0, time.UTC)
+
+var (
+	moonNamesKR = []string{"삭", "초승달", "상현", "차가는 달", "보름", "기우는 달", "하현", "그믐달"}
+	moonNamesEN = []string{"new moon", "waxing crescent", "first quarter", "waxing gibbous", "full moon", "waning gibbous", "last quarter", "waning crescent"}
+	moonEmoji   = []string{"🌑", "🌒", "🌓", "🌔", "🌕", "🌖", "🌗", "🌘"}
+)
+
+// moonPhase는 t 시점의 위상 번호 (0=삭 ... 7=그믐달)와 밝기 비율(0~1)이다.
+// 이름과 이모지는 번호로 moonNamesKR/EN, moonEmoji 에서 찾는다.
+// 텍스트, 마크다운, a11y 출력이 모두 이 함수로 계산하므로 위상 경계에서도 같은 위상을 보여준다.
+func moonPhase(t time.Time) (int, float64) {
+	days := t.Sub(knownNewMoon).Hours() / 24
+	age := math.Mod(days, synodicMonth)
+	if age < 0 {
+		age += synodicMonth
+	}
+
+	illum := (1 - math.Cos(2*math.Pi*age/synodicMonth)) / 2
+
+	// 8등분, 각 구간의 중앙이 대표 위상이 되도록 반 칸 밀어준다.
+	return int(math.Floor(age/synodicMonth*8+0.5)) % 8, illum
+}
+
+// moonText는 "🌕 보름 (100%)" 이다. (ascii면 이모지 없이) 텍스트와 마크다운이 같이 쓴다.
+func moonText(t time.Time, opts Options) string {
+	i, illum := moonPhase(t)
+	text := fmt.Sprintf("%s (%.0f%%)", opts.dual(moonNamesKR[i], moonNamesEN[i]), illum*100)
+	if opts.ascii() {
+		return text
+	}
+	return moonEmoji[i] + " " + text
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// 실제 보름/삭/상현/하현 시각 (UTC) 에서 위상 번호와 밝기를 본다.
+// 평균 삭망월로 계산하므로 상현/하현 밝기는 실제 (50%)와 몇 % 차이가 난다.
+func TestMoonPhaseKnownDates(t *testing.T) {
+	cases := []struct {
+		name     string
+		at       time.Time
+		phase    int
+		illumMin float64
+		illumMax float64
+	}{
+		{"full 2024-01-25", time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC), 4, 0.99, 1},
+		{"full 2024-09-18", time.Date(2024, 9, 18, 2, 34, 0, 0, time.UTC), 4, 0.99, 1},
+		{"full 2025-03-14", time.Date(2025, 3, 14, 6, 55, 0, 0, time.UTC), 4, 0.99, 1},
+		{"full 2026-05-31", time.Date(2026, 5, 31, 8, 45, 0, 0, time.UTC), 4, 0.99, 1},
+		{"new 2024-04-08", time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC), 0, 0, 0.01},
+		{"new 2025-03-29", time.Date(2025, 3, 29, 10, 58, 0, 0, time.UTC), 0, 0, 0.01},
+		{"first quarter 2024-01-18", time.Date(2024, 1, 18, 3, 53, 0, 0, time.UTC), 2, 0.4, 0.6},
+		{"last quarter 2024-02-02", time.Date(2024, 2, 2, 23, 18, 0, 0, time.UTC), 6, 0.4, 0.6},
+		{"before epoch", time.Date(1999, 12, 22, 17, 31, 0, 0, time.UTC), 4, 0.99, 1}, // 기준 삭 이전 보름
+	}
+	for _, c := range cases {
+		phase, illum := moonPhase(c.at)
+		if phase != c.phase {
+			t.Errorf("%s: phase = %d (%s), want %d (%s)", c.name, phase, moonNamesEN[phase], c.phase, moonNamesEN[c.phase])
+		}
+		if illum < c.illumMin || illum > c.illumMax {
+			t.Errorf("%s: illum = %.3f, want %.2f..%.2f", c.name, illum, c.illumMin, c.illumMax)
+		}
+	}
+}
+
+// 텍스트와 마크다운은 같은 moonText를 쓰므로 위상 경계 근처에서도 같은 값을 보여준다.
+func TestMoonTextMatchesMarkdown(t *testing.T) {
+	opts := testOptions()
+	opts.Moon = true
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, kst)
+	for h := 0; h < 24*30; h += 5 {
+		at := start.Add(time.Duration(h) * time.Hour)
+		r := testReport()
+		r.Time = at
+
+		text := strings.TrimSuffix(captureStdout(t, func() { printMoon(at, opts) }), "\n")
+		text = strings.TrimPrefix(text, opts.label("moon")+" ")
+		if md := markdownSummary(r, opts); !strings.Contains(md, "| "+text+" |") {
+			t.Fatalf("%s: markdown has no moon row %q:\n%s", at, text, md)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ---------- 평년 비교 (--vs-normal) ----------
+// 진짜 평년값(30년)이 아니라 최근 normalYears년 같은 날 같은 시각 기온의 평균이다.
+// 요청 수를 줄이기 위한 근사치이며, 과거 자료는 바뀌지 않으므로 만료 없이 캐시한다.
+const normalYears = 5
+
+type normalTemp struct {
+	Mean  float64
+	Years int // 평균에 쓰인 연도 수 (결측 연도 제외)
+}
+
+type archiveResponse struct {
+	Hourly struct {
+		Time        []string   `json:"time"`
+		Temperature []*float64 `json:"temperature_2m"`
+	} `json:"hourly"`
+}
+
+func buildArchiveURL(base string, lat, lon float64, date string, opts queryOpts) string {
+	opts.StartDate, opts.EndDate = date, date
+	return base + "?" + coordValues(lat, lon, opts).Encode()
+}
+
+// fetchNormal은 최근 normalYears년의 같은 날짜를 병렬로 받아 now.Hour() 기온을 평균낸다.
+func fetchNormal(ctx context.Context, client *http.Client, loc GeoResult, now time.Time, opts Options) (*normalTemp, error) {
+	temps := make([]*float64, normalYears)
+
+	var g errgroup.Group
+	for i := range normalYears {
+		day := time.Date(now.Year()-1-i, now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if day.Month() != now.Month() {
+			continue // 2월 29일이 없는 해
+		}
+
+		g.Go(catchPanic(func() error {
+			hours, err := fetchArchiveDay(ctx, client, loc, day.Format("2006-01-02"), opts)
+			if err == nil && now.Hour() < len(hours) {
+				temps[i] = hours[now.Hour()]
+			}
+			return nil // 실패한 해는 평균에서 제외한다.
+		}))
+	}
+	rethrowPanic(g.Wait())
+
+	mean, n := meanPresent(temps)
+	if n == 0 {
+		return nil, fmt.Errorf("no archive data for %s", now.Format("01-02"))
+	}
+	return &normalTemp{Mean: mean, Years: n}, nil
+}
+
+// fetchArchiveDay는 하루치 시간별 기온(최대 24개, 결측은 nil)을 돌려준다.
+func fetchArchiveDay(ctx context.Context, client *http.Client, loc GeoResult, date string, opts Options) ([]*float64, error) {
+	q := queryOpts{Timezone: "Asia/Seoul", Hourly: []string{"temperature_2m"}}
+	if opts.imperial() {
+		q.TemperatureUnit = "fahrenheit"
+	}
+
+	cache := opts.cache()
+	key := fmt.Sprintf("%.2f,%.2f|%s|%s", loc.Latitude, loc.Longitude, date, q.TemperatureUnit)
+
+	var hours []*float64
+	if cache.get("archive", key, 0, &hours) {
+		return hours, nil
+	}
+
+	urls := mirrorURLs(endpoints.Archive, func(base string) string {
+		return buildArchiveURL(base, loc.Latitude, loc.Longitude, date, q)
+	})
+	resp, err := fetchWithFallback(ctx, client, urls)
+	if err != nil {
+		return nil, requestError("archive", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError("archive", resp)
+	}
+
+	var data archiveResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, decodeError("archive", err)
+	}
+
+	hours = data.Hourly.Temperature
+	_ = cache.put("archive", key, hours)
+	return hours, nil
+}
+
+// meanPresent는 nil(결측)을 뺀 평균과 사용한 값의 개수를 돌려준다.
+func meanPresent(vals []*float64) (mean float64, n int) {
+	var sum float64
+	for _, v := range vals {
+		if v == nil {
+			continue
+		}
+		sum += *v
+		n++
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return sum / float64(n), n
+}
+
+func printVsNormal(w Current, normal *normalTemp, opts Options) {
+	diff := w.Temperature2m - normal.Mean
+	unit := tempSymbol(opts)
+	p := opts.Precision
+
+	switch {
+	case diff >= 0.5:
+		fmt.Printf("평년보다 %s%s 높음", p.format("temp", diff), unit)
+	case diff <= -0.5:
+		fmt.Printf("평년보다 %s%s 낮음", p.format("temp", -diff), unit)
+	default:
+		fmt.Print("평년과 비슷함")
+	}
+	fmt.Printf(" (최근 %d년 같은 시각 평균 %s%s)\n", normal.Years, p.format("temp", normal.Mean), unit)
+}
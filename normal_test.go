@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMeanPresent(t *testing.T) {
+	cases := []struct {
+		name string
+		vals []*float64
+		mean float64
+		n    int
+	}{
+		{"empty", nil, 0, 0},
+		{"all missing", []*float64{nil, nil}, 0, 0},
+		{"all present", []*float64{ptr(10.0), ptr(12.0), ptr(14.0)}, 12, 3},
+		{"missing excluded", []*float64{ptr(10.0), nil, ptr(13.0), nil}, 11.5, 2},
+		{"zero is a value", []*float64{ptr(0.0), ptr(-4.0)}, -2, 2},
+	}
+	for _, c := range cases {
+		mean, n := meanPresent(c.vals)
+		if mean != c.mean || n != c.n {
+			t.Errorf("%s: meanPresent = (%v, %d), want (%v, %d)", c.name, mean, n, c.mean, c.n)
+		}
+	}
+}
+
+// 실패한 해(5xx)와 그 시각 값이 비어 있는 해는 평균에서 빠진다.
+func TestFetchNormalSkipsMissingYears(t *testing.T) {
+	byDate := map[string]*float64{ // 15시 기온
+		"2024-03-14": ptr(10.0),
+		"2023-03-14": ptr(12.0),
+		"2022-03-14": nil,
+		// 2021: 500
+		"2020-03-14": ptr(14.0),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("start_date")
+		v, ok := byDate[date]
+		if !ok {
+			http.Error(w, "unavailable", http.StatusInternalServerError)
+			return
+		}
+		var res archiveResponse
+		res.Hourly.Temperature = make([]*float64, 24)
+		res.Hourly.Temperature[15] = v
+		json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+	orig := endpoints
+	endpoints.Archive = []string{srv.URL}
+	defer func() { endpoints = orig }()
+
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, kst)
+	got, err := fetchNormal(context.Background(), srv.Client(), GeoResult{Latitude: 37.566, Longitude: 126.9784}, now, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Mean != 12 || got.Years != 3 {
+		t.Errorf("normal = %+v, want mean 12 over 3 years", *got)
+	}
+
+	byDate = map[string]*float64{}
+	if _, err := fetchNormal(context.Background(), srv.Client(), GeoResult{Latitude: 37.566, Longitude: 126.9784}, now, testOptions()); err == nil {
+		t.Error("expected error when every year is missing")
+	}
+}
+
+func TestPrintVsNormal(t *testing.T) {
+	cases := []struct {
+		temp float64
+		want string
+	}{
+		{14.3, "평년보다 2.3°C 높음 (최근 3년 같은 시각 평균 12.0°C)\n"},
+		{10.1, "평년보다 1.9°C 낮음 (최근 3년 같은 시각 평균 12.0°C)\n"},
+		{12.4, "평년과 비슷함 (최근 3년 같은 시각 평균 12.0°C)\n"},
+		{11.6, "평년과 비슷함 (최근 3년 같은 시각 평균 12.0°C)\n"},
+	}
+	for _, c := range cases {
+		got := captureStdout(t, func() {
+			printVsNormal(Current{Temperature2m: c.temp}, &normalTemp{Mean: 12, Years: 3}, testOptions())
+		})
+		if got != c.want {
+			t.Errorf("%v: got %q, want %q", c.temp, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------- Output ----------
+// Report는 한 번의 조회 결과를 묶는다.
+type Report struct {
+	Location GeoResult
+	Time     time.Time // 조회 시각 (KST)
+	Weather  Current
+	Air      AirQualityCurrent
+
+	// best-effort 모드에서 실패한 부분 (nil이면 정상)
+	WeatherErr error
+	AirErr     error
+
+	// Normal은 --vs-normal 비교 기준이다. (없으면 nil)
+	Normal *normalTemp
+
+	// Meta는 이번 조회에서 받은 API 응답 헤더 (Date, X-*)다. 캐시만 썼으면 비어 있다.
+	Meta []apiMeta
+}
+
+// Outputter는 Report를 특정 형식으로 출력한다.
+type Outputter interface {
+	Output(r Report) error
+}
+
+func newOutputter(opts Options) Outputter {
+	if opts.Template != nil {
+		return templateOutputter{opts: opts, tmpl: opts.Template}
+	}
+	if opts.JSON {
+		return jsonOutputter{opts: opts, compact: opts.JSONLines || opts.JSONCompact}
+	}
+	if opts.Markdown {
+		return markdownOutputter{opts: opts}
+	}
+	if opts.A11y {
+		return a11yOutputter{opts: opts}
+	}
+	return textOutputter{opts: opts}
+}
+
+// textOutputter는 기본 (이모지 포함) 요약 출력이다.
+type textOutputter struct {
+	opts Options
+}
+
+func (o textOutputter) Output(r Report) error {
+	printSummary(r, o.opts)
+	return nil
+}
+
+// printSummary는 섹션 함수들을 순서대로 호출한다.
+// 각 섹션은 독립적으로 켜고 끌 수 있고, --summary-order 로 순서를 바꿀 수 있다.
+func printSummary(r Report, opts Options) {
+	if opts.DetailLevel == detailOneLine {
+		printOneLine(r, opts)
+		return
+	}
+
+	if !opts.NoHeader {
+		printHeader(r.Location, r.Time, opts)
+	}
+	for _, name := range sectionOrder(opts.Sections) {
+		summarySections[name](r, opts)
+	}
+}
+
+// ---------- Summary sections ----------
+// defaultSectionOrder는 기본 출력 순서다. --summary-order 에 없는 섹션은 이 순서로 뒤에 붙는다.
+var defaultSectionOrder = []string{"temp", "rain", "wind", "extra", "normal", "aqi", "sun", "moon", "advice"}
+
+// summarySections는 섹션 이름별 출력 함수다. 날씨/대기질이 실패했으면 해당 섹션은 안내 문구만 내거나 건너뛴다.
+var summarySections = map[string]func(r Report, opts Options){
+	"temp": func(r Report, opts Options) {
+		if r.WeatherErr != nil {
+			fmt.Println(opts.label("no_weather"))
+			return
+		}
+		printWeather(r.Weather, r.Time, opts)
+		printTempAlerts(r.Weather, opts)
+		if opts.HeatIndex {
+			if s := heatIndexText(r.Weather, opts); s != "" {
+				fmt.Println(s)
+			}
+		}
+		if opts.WindChill {
+			if s := windChillText(r.Weather, opts); s != "" {
+				fmt.Println(s)
+			}
+		}
+	},
+	"rain": func(r Report, opts Options) {
+		if opts.PrecipWindow > 0 && r.WeatherErr == nil {
+			fmt.Println(rainOutlook(r.Weather, opts.PrecipWindow, opts.PrecipThreshold))
+		}
+	},
+	"wind": func(r Report, opts Options) {
+		if r.WeatherErr == nil {
+			printWind(r.Weather, opts)
+		}
+	},
+	"extra": func(r Report, opts Options) {
+		if opts.DetailLevel >= detailExtra && r.WeatherErr == nil {
+			printExtraWeather(r.Weather, opts)
+		}
+	},
+	"normal": func(r Report, opts Options) {
+		if r.Normal != nil && r.WeatherErr == nil {
+			printVsNormal(r.Weather, r.Normal, opts)
+		}
+	},
+	"aqi": func(r Report, opts Options) {
+		if r.AirErr != nil {
+			fmt.Println(opts.label("no_air"))
+			return
+		}
+		printAir(r.Air, opts)
+		if opts.TopPollutant {
+			fmt.Println(topPollutantText(r.Air))
+		}
+		if opts.DetailLevel >= detailAll {
+			printGases(r.Air, opts)
+		}
+	},
+	"sun": func(r Report, opts Options) {
+		if r.WeatherErr != nil {
+			return
+		}
+		if opts.DetailLevel >= detailAll {
+			printSunTimes(r.Weather)
+		}
+		if opts.SinceSunrise {
+			if s := daylightText(r.Weather, r.Time); s != "" {
+				fmt.Println(s)
+			}
+		}
+	},
+	"moon": func(r Report, opts Options) {
+		if opts.Moon || opts.DetailLevel >= detailAll {
+			printMoon(r.Time, opts)
+		}
+	},
+	"advice": func(r Report, opts Options) {
+		if opts.Advice && r.WeatherErr == nil && r.AirErr == nil {
+			printAdvice(r.Weather, r.Air, opts)
+		}
+	},
+}
+
+// parseSummaryOrder는 --summary-order 쉼표 목록을 검사한다.
+func parseSummaryOrder(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := summarySections[name]; !ok {
+			return nil, fmt.Errorf("unknown --summary-order section %q (%s)", name, strings.Join(defaultSectionOrder, ", "))
+		}
+		if slices.Contains(names, name) {
+			return nil, fmt.Errorf("duplicate --summary-order section %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// sectionOrder는 order의 섹션을 먼저, 나머지는 기본 순서로 돌려준다.
+func sectionOrder(order []string) []string {
+	out := slices.Clone(order)
+	for _, name := range defaultSectionOrder {
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func printHeader(loc GeoResult, now time.Time, opts Options) {
+	name := loc.Name
+	if loc.Approximate {
+		name += " (" + opts.label("approximate") + ")"
+	}
+	if loc.Rounded > 0 {
+		ko, en := msg("rounded")
+		note := fmt.Sprintf(ko, loc.Rounded)
+		if opts.DualLang {
+			note += "/" + fmt.Sprintf(en, loc.Rounded)
+		}
+		name += " (" + note + ")"
+	}
+	if loc.Query != "" {
+		name += " (" + opts.labelInline("query") + ": " + loc.Query + ")"
+	}
+	fmt.Printf("%s | %s (KST)\n",
+		name,
+		now.Format("01-02 15:04"),
+	)
+	for _, line := range zoneLines(now, opts.Zones) {
+		fmt.Println(line)
+	}
+}
+
+func printWeather(w Current, now time.Time, opts Options) {
+	p := opts.Precision
+	unit := tempSymbol(opts)
+	temps := fmt.Sprintf("%s%s%s (%s %s%s)",
+		p.format("temp", w.Temperature2m), unit, trendText(w, opts),
+		opts.labelInline("feels"), p.format("temp", w.ApparentTemperature), unit)
+	if opts.FeelsFirst {
+		temps = fmt.Sprintf("%s %s%s %s (%s %s%s%s)",
+			opts.label("feels"), p.format("temp", w.ApparentTemperature), unit, comfortLabel(w.apparentCelsius(opts)),
+			opts.labelInline("actual"), p.format("temp", w.Temperature2m), unit, trendText(w, opts))
+	}
+	fmt.Printf("%s  %s%s  |  %s %s%s\n",
+		opts.dual(conditionText(w.WeatherCode, w.isNight(now), opts), conditionEN(w.WeatherCode)),
+		temps, pastRecap(w, opts),
+		opts.label("precip"), precipText(w.PrecipProbability, opts), rainText(w, opts),
+	)
+
+	vis := opts.label("visibility")
+	if w.Visibility == nil {
+		fmt.Println(vis + " --")
+		return
+	}
+	km := metersToKm(*w.Visibility)
+	if opts.imperial() {
+		fmt.Printf("%s %smi (%s)\n", vis, p.format("visibility", metersToMiles(*w.Visibility)), visibilityGradeKR(km))
+		return
+	}
+	fmt.Printf("%s %skm (%s)\n", vis, p.format("visibility", km), visibilityGradeKR(km))
+}
+
+// precipText는 "80%" 같은 강수 확률이다. 이 지역 모델이 확률을 주지 않으면 "-- (확률 미제공)" 이다.
+// --round-down-precip 이면 99.6 이 100 이 되지 않게 내림하고 구간과 원값을 붙인다. 예: "99% (매우 높음, 원값 99.6%)"
+func precipText(prob *float64, opts Options) string {
+	if prob == nil {
+		return "-- (" + opts.labelInline("no_prob") + ")"
+	}
+	v := *prob
+	p := opts.Precision
+	if !opts.RoundDownPrecip {
+		return p.format("precip", opts.bucketPrecip(v)) + "%"
+	}
+	floored := p.floor("precip", opts.bucketPrecip(v))
+	raw := strconv.FormatFloat(v, 'f', -1, 64)
+	if floored == raw {
+		return fmt.Sprintf("%s%% (%s)", floored, precipBandKR(v))
+	}
+	return fmt.Sprintf("%s%% (%s, %s %s%%)", floored, precipBandKR(v), opts.labelInline("raw"), raw)
+}
+
+// bucketPrecip은 --precip-step 간격으로 반올림한 (--round-down-precip 이면 내림한) 강수 확률이다.
+func (o Options) bucketPrecip(v float64) float64 {
+	if o.PrecipStep <= 0 {
+		return v
+	}
+	round := math.Round
+	if o.RoundDownPrecip {
+		round = math.Floor
+	}
+	return math.Min(round(v/o.PrecipStep)*o.PrecipStep, 100)
+}
+
+// rainText는 비가 오고 있으면 " (보통비 4.2mm/h)" 처럼 강도와 양을 돌려준다.
+func rainText(w Current, opts Options) string {
+	if w.Precipitation == nil || *w.Precipitation <= 0 {
+		return ""
+	}
+	mm := *w.Precipitation
+	if opts.imperial() {
+		return fmt.Sprintf(" (%s %sin/h)", precipIntensityKR(mm), fmt.Sprintf("%.*f", opts.Precision.decimals("rain")+1, mmToInches(mm)))
+	}
+	return fmt.Sprintf(" (%s %smm/h)", precipIntensityKR(mm), opts.Precision.format("rain", mm))
+}
+
+func printAir(aq AirQualityCurrent, opts Options) {
+	if opts.CompactAir {
+		fmt.Println(compactAirLine(aq, opts))
+		return
+	}
+
+	fmt.Println(airStatusLine(aq, opts))
+
+	pm10, lo10, hi10 := pm10GradeKR(aq.PM10)
+	pm25, lo25, hi25 := pm25GradeKR(aq.PM25)
+	pm10Text := opts.colorGrade(pm10, opts.dual(pm10, gradeEN(pm10)))
+	pm25Text := opts.colorGrade(pm25, opts.dual(pm25, gradeEN(pm25)))
+	pm25Text += pm25AvgText(aq, opts)
+	if opts.Explain {
+		fmt.Printf("%s %s (%s)\n", opts.label("pm10"), pm10Text, explainRange(lo10, hi10, aq.PM10, " ㎍/m³"))
+		fmt.Printf("%s %s (%s)\n", opts.label("pm25"), pm25Text, explainRange(lo25, hi25, aq.PM25, " ㎍/m³"))
+		return
+	}
+	fmt.Printf("%s %s | %s %s\n", opts.label("pm10"), pm10Text, opts.label("pm25"), pm25Text)
+}
+
+// pm25AvgText는 --pm25-24h 의 " (24시간 평균 22.1 보통)" 이다.
+// 자료가 모자라면 " (최근 12시간 평균 22.1 보통, 자료 부족)" 처럼 쓴 시간 수를 밝힌다.
+func pm25AvgText(aq AirQualityCurrent, opts Options) string {
+	if !opts.PM25Avg || aq.PM25Avg24h == nil {
+		return ""
+	}
+	avg := *aq.PM25Avg24h
+	grade, _, _ := pm25GradeKR(avg)
+	v := opts.Precision.format("pm25", avg)
+	if aq.PM25AvgHours < pm25AvgMinHours {
+		return fmt.Sprintf(" (최근 %d시간 평균 %s %s, 자료 부족)", aq.PM25AvgHours, v, grade)
+	}
+	return fmt.Sprintf(" (24시간 평균 %s %s)", v, grade)
+}
+
+// airStatusLine은 대기질 첫 줄이다. --aqi-standard=eu 인데 european_aqi가 없으면 US AQI로 보여준다.
+func airStatusLine(aq AirQualityCurrent, opts Options) string {
+	if opts.AQIStandard == aqiStandardEU && aq.AQIEU != nil {
+		return euAirStatusLine(*aq.AQIEU, opts)
+	}
+
+	aqi, estimated := aq.usAQI()
+	explain := ""
+	if opts.Explain {
+		_, lo, hi := gradeRange(float64(aqi), thresholds.AQI)
+		explain = " (" + explainRange(lo, hi, float64(aqi), "") + ")"
+	}
+	status := opts.colorGrade(aqiLabel(aqi), opts.dual(aqiText(aqi, opts), aqiStatusEN(aqi)))
+	if estimated {
+		return fmt.Sprintf("%s %s (AQI ~%d, %s)%s", opts.label("air"), status, aqi, opts.labelInline("pm25_based"), explain)
+	}
+	return fmt.Sprintf("%s %s (AQI %d)%s", opts.label("air"), status, aqi, explain)
+}
+
+// compactAirLine은 대기질/PM10/PM2.5를 한 줄로 접는다. (--compact-air, --explain 무시)
+// 예: 대기질 보통 | PM10 45 보통 | PM2.5 22 보통
+func compactAirLine(aq AirQualityCurrent, opts Options) string {
+	p := opts.Precision
+	aqi, estimated := aq.usAQI()
+	label := opts.dual(aqiLabel(aqi), aqiNamesEN[gradeIndex(float64(aqi), thresholds.AQI)])
+	if estimated {
+		label += " (" + opts.labelInline("estimated") + ")"
+	}
+	pm10, _, _ := pm10GradeKR(aq.PM10)
+	pm25, _, _ := pm25GradeKR(aq.PM25)
+	return fmt.Sprintf("%s %s | PM10 %s %s | PM2.5 %s %s",
+		opts.label("air"), label,
+		p.format("pm10", aq.PM10), opts.dual(pm10, gradeEN(pm10)),
+		p.format("pm25", aq.PM25), opts.dual(pm25, gradeEN(pm25)),
+	)
+}
+
+func printMoon(now time.Time, opts Options) {
+	fmt.Println(opts.label("moon") + " " + moonText(now, opts))
+}
+
+func printDryRun(loc GeoResult, opts Options) {
+	name := loc.Name
+	if loc.Country != "" {
+		name += ", " + loc.Country
+	}
+	fmt.Printf("%s (%.4f, %.4f)\n", name, loc.Latitude, loc.Longitude)
+	fmt.Println("GET " + buildForecastURL(endpoints.Forecast[0], loc.Latitude, loc.Longitude, forecastQuery(opts)))
+	fmt.Println("GET " + buildAirURL(endpoints.Air[0], loc.Latitude, loc.Longitude, airQuery(opts, opts.DetailLevel >= detailAll)))
+}
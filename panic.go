@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"slices"
+)
+
+// ---------- Panic handling ----------
+// 예상 못 한 panic은 스택 대신 짧은 메시지로 바꾼다.
+// 스택은 --verbose 나 $WEATHER_DEBUG 가 있을 때만 출력한다. (버그 리포트용)
+
+// goroutinePanic은 errgroup 고루틴의 panic을 main 고루틴까지 옮긴다.
+// 고루틴 안의 panic은 main의 recover로 잡을 수 없기 때문이다.
+type goroutinePanic struct {
+	value any
+	stack []byte
+}
+
+func (p *goroutinePanic) Error() string {
+	return fmt.Sprintf("panic: %v", p.value)
+}
+
+// catchPanic은 fn의 panic을 *goroutinePanic 에러로 바꾼다.
+func catchPanic(fn func() error) func() error {
+	return func() (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if gp, ok := r.(*goroutinePanic); ok {
+				err = gp // 안쪽 고루틴에서 옮겨 온 panic은 원래 스택을 유지한다.
+				return
+			}
+			err = &goroutinePanic{value: r, stack: debug.Stack()}
+		}()
+		return fn()
+	}
+}
+
+// rethrowPanic은 err가 고루틴 panic이면 현재 고루틴에서 다시 panic한다.
+func rethrowPanic(err error) {
+	var gp *goroutinePanic
+	if errors.As(err, &gp) {
+		panic(gp)
+	}
+}
+
+// handlePanic은 main에서 defer로 호출한다.
+func handlePanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "error: unexpected internal error")
+	if debugEnabled() {
+		value, stack := r, debug.Stack()
+		if gp, ok := r.(*goroutinePanic); ok {
+			value, stack = gp.value, gp.stack
+		}
+		fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", value, stack)
+	} else {
+		fmt.Fprintln(os.Stderr, "(--verbose 또는 WEATHER_DEBUG=1 로 실행하면 자세한 정보를 볼 수 있습니다)")
+	}
+	os.Exit(2)
+}
+
+// debugEnabled는 flag 파싱 전이나 도중에도 쓸 수 있도록 os.Args를 직접 본다.
+func debugEnabled() bool {
+	if os.Getenv("WEATHER_DEBUG") != "" {
+		return true
+	}
+	return slices.ContainsFunc(os.Args[1:], func(a string) bool {
+		return a == "--verbose" || a == "-verbose" || a == "--verbose=true" || a == "-verbose=true"
+	})
+}
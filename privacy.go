@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ---------- Coordinate privacy ----------
+// --round-coordinates-privacy 면 위치를 --privacy-grid 간격으로 반올림한 뒤 날씨/대기질 API를 부른다.
+// 0.1°는 위도 방향 약 11km다.
+const defaultPrivacyGrid = 0.1
+
+// roundLocation은 좌표를 step 격자로 반올림하고 Rounded에 step을 남긴다. (머리줄 표시용)
+func roundLocation(loc GeoResult, step float64) GeoResult {
+	loc.Latitude = roundToGrid(loc.Latitude, step)
+	loc.Longitude = roundToGrid(loc.Longitude, step)
+	loc.Rounded = step
+	return loc
+}
+
+// roundToGrid는 0.1 * 376 = 37.6000000001 같은 오차를 소수 6자리에서 정리한다.
+func roundToGrid(v, step float64) float64 {
+	r := math.Round(math.Round(v/step)*step*1e6) / 1e6
+	if r == 0 {
+		return 0 // -0
+	}
+	return r
+}
+
+func validPrivacyGrid(step float64) error {
+	if step <= 0 || step > 1 {
+		return fmt.Errorf("invalid --privacy-grid %v (0 초과 ~ 1 degrees)", step)
+	}
+	return nil
+}
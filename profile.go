@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ---------- Profiles ----------
+// Profile은 자주 보는 위치를 이름으로 저장한 것이다. (config.json의 "profiles")
+// Unit, Lang은 비어 있으면 전역 설정을 따른다.
+type Profile struct {
+	City string `json:"city"`
+	Unit string `json:"unit,omitempty"`
+	Lang string `json:"lang,omitempty"`
+}
+
+func (p Profile) validate() error {
+	if strings.TrimSpace(p.City) == "" {
+		return fmt.Errorf("city required")
+	}
+	if p.Unit != "" && !validUnit(p.Unit) {
+		return fmt.Errorf("invalid unit %q (c, f, auto)", p.Unit)
+	}
+	if p.Lang != "" && !validLang(p.Lang) {
+		return fmt.Errorf("invalid lang %q (ko, en)", p.Lang)
+	}
+	return nil
+}
+
+func validProfileName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// applyProfile은 프로필 값을 opts와 city에 채운다.
+// 명령줄에서 직접 준 값(city 인자, --unit, --lang)이 프로필보다 우선한다.
+func applyProfile(p Profile, city string, opts *Options, setFlags map[string]bool) string {
+	if p.Unit != "" && !setFlags["unit"] {
+		opts.Unit = p.Unit
+	}
+	if p.Lang != "" && !setFlags["lang"] && !setFlags["locale"] {
+		opts.Lang = p.Lang
+	}
+	if city == "" {
+		return p.City
+	}
+	return city
+}
+
+// addProfile은 도시가 실제로 지오코딩되는지 확인한 뒤 저장한다.
+func addProfile(ctx context.Context, path, name string, p Profile, opts Options) (GeoResult, error) {
+	if !validProfileName(name) {
+		return GeoResult{}, fmt.Errorf("invalid profile name %q (a-z, 0-9, -, _)", name)
+	}
+	if err := p.validate(); err != nil {
+		return GeoResult{}, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return GeoResult{}, err
+	}
+
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return GeoResult{}, err
+	}
+	if p.Lang != "" {
+		opts.Lang = p.Lang
+	}
+	loc, err := resolveCity(ctx, client, p.City, opts)
+	if err != nil {
+		return GeoResult{}, fmt.Errorf("profile %s: %w", name, err)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+	cfg.Profiles[name] = p
+	return loc, saveConfig(path, cfg)
+}
+
+func removeProfile(path, name string) error {
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("unknown profile %q", name)
+	}
+	delete(cfg.Profiles, name)
+	return saveConfig(path, cfg)
+}
+
+// profileNames는 이름순으로 정렬한다.
+func profileNames(cfg Config) []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func printProfiles(cfg Config) {
+	names := profileNames(cfg)
+	if len(names) == 0 {
+		fmt.Println("저장된 프로필이 없습니다. (weather profile add <name> <city>)")
+		return
+	}
+	for _, name := range names {
+		p := cfg.Profiles[name]
+		line := padRight(name, 12) + " " + p.City
+		var extra []string
+		if p.Unit != "" {
+			extra = append(extra, "unit="+p.Unit)
+		}
+		if p.Lang != "" {
+			extra = append(extra, "lang="+p.Lang)
+		}
+		if len(extra) > 0 {
+			line += " (" + strings.Join(extra, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+// add → 파일 → load/list → remove 까지 프로필이 그대로 남는지 본다.
+func TestProfileRoundTrip(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+	path := filepath.Join(t.TempDir(), "config.json")
+	ctx := context.Background()
+
+	profiles := map[string]Profile{
+		"home": {City: "seoul", Unit: "f"},
+		"work": {City: "busan", Lang: "en"},
+	}
+	for name, p := range profiles {
+		loc, err := addProfile(ctx, path, name, p, testOptions())
+		if err != nil {
+			t.Fatalf("add %s: %v", name, err)
+		}
+		if loc.Name == "" {
+			t.Errorf("add %s: resolved location has no name", name)
+		}
+	}
+
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != len(profiles) {
+		t.Fatalf("loaded %d profiles, want %d", len(cfg.Profiles), len(profiles))
+	}
+	for name, want := range profiles {
+		if got := cfg.Profiles[name]; got != want {
+			t.Errorf("%s = %+v, want %+v", name, got, want)
+		}
+	}
+
+	got := captureStdout(t, func() { printProfiles(cfg) })
+	const want = "home         seoul (unit=f)\nwork         busan (lang=en)\n"
+	if got != want {
+		t.Errorf("profile list:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := removeProfile(path, "home"); err != nil {
+		t.Fatal(err)
+	}
+	if err := removeProfile(path, "home"); err == nil {
+		t.Error("removing a missing profile should fail")
+	}
+	cfg, _ = loadConfig(path)
+	if _, ok := cfg.Profiles["home"]; ok || cfg.Profiles["work"] != profiles["work"] {
+		t.Errorf("after remove: %+v", cfg.Profiles)
+	}
+}
+
+// 이름이 잘못됐거나 도시를 찾을 수 없으면 저장하지 않는다.
+func TestAddProfileRejects(t *testing.T) {
+	fakeOpenMeteo(t, batchPlaces)
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	cases := []struct {
+		name string
+		p    Profile
+	}{
+		{"Home", Profile{City: "seoul"}},
+		{"my home", Profile{City: "seoul"}},
+		{"home", Profile{City: ""}},
+		{"home", Profile{City: "seoul", Unit: "k"}},
+		{"home", Profile{City: "seoul", Lang: "jp"}},
+		{"home", Profile{City: "nowhere"}},
+	}
+	for _, c := range cases {
+		if _, err := addProfile(context.Background(), path, c.name, c.p, testOptions()); err == nil {
+			t.Errorf("addProfile(%q, %+v) succeeded, want error", c.name, c.p)
+		}
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != 0 {
+		t.Errorf("rejected profiles were saved: %+v", cfg.Profiles)
+	}
+}
+
+// 명령줄에서 직접 준 city, --unit, --lang 이 프로필보다 우선한다.
+func TestApplyProfile(t *testing.T) {
+	p := Profile{City: "seoul", Unit: "f", Lang: "en"}
+	cases := []struct {
+		city       string
+		set        map[string]bool
+		wantCity   string
+		unit, lang string
+	}{
+		{"", nil, "seoul", "f", "en"},
+		{"busan", nil, "busan", "f", "en"},
+		{"", map[string]bool{"unit": true}, "seoul", "c", "en"},
+		{"", map[string]bool{"lang": true}, "seoul", "f", "ko"},
+		{"", map[string]bool{"locale": true}, "seoul", "f", "ko"},
+	}
+	for _, c := range cases {
+		opts := testOptions()
+		city := applyProfile(p, c.city, &opts, c.set)
+		if city != c.wantCity || opts.Unit != c.unit || opts.Lang != c.lang {
+			t.Errorf("applyProfile(%q, %v) = %s unit=%s lang=%s, want %s unit=%s lang=%s",
+				c.city, c.set, city, opts.Unit, opts.Lang, c.wantCity, c.unit, c.lang)
+		}
+	}
+}
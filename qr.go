@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// ---------- QR ----------
+// --qr 은 위치의 지도 URL을 QR 코드로 터미널에 그린다. (휴대폰으로 같은 위치 열기)
+// 터미널이 아닐 때(파이프, 파일)는 그리지 않는다.
+//
+// 밝은 칸을 글자로 채우므로 어두운 배경의 터미널 기준이다.
+// emoji 아이콘 세트는 반 블록(▀▄█)으로 두 줄을 한 줄에, ascii는 "##"로 그린다.
+
+func locationMapURL(loc GeoResult) string {
+	return fmt.Sprintf("https://www.openstreetmap.org/?mlat=%.5f&mlon=%.5f#map=12/%.5f/%.5f",
+		loc.Latitude, loc.Longitude, loc.Latitude, loc.Longitude)
+}
+
+// renderQR은 text를 QR 코드 문자열로 만든다. (quiet zone 포함)
+func renderQR(text string, ascii bool) (string, error) {
+	q, err := qrcode.New(text, qrcode.Medium)
+	if err != nil {
+		return "", fmt.Errorf("qr encode failed: %w", err)
+	}
+	bm := q.Bitmap() // true: 어두운 칸
+
+	var b strings.Builder
+	if ascii {
+		for _, row := range bm {
+			for _, dark := range row {
+				if dark {
+					b.WriteString("  ")
+				} else {
+					b.WriteString("##")
+				}
+			}
+			b.WriteByte('\n')
+		}
+		return b.String(), nil
+	}
+
+	for y := 0; y < len(bm); y += 2 {
+		for x := range bm[y] {
+			top := !bm[y][x]
+			bottom := y+1 < len(bm) && !bm[y+1][x]
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+func printQR(loc GeoResult, opts Options) error {
+	u := locationMapURL(loc)
+	s, err := renderQR(u, opts.ascii())
+	if err != nil {
+		return err
+	}
+	fmt.Print(s)
+	fmt.Println(u)
+	return nil
+}
+
+// isTerminal은 f가 문자 장치(터미널)인지 본다.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
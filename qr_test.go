@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocationMapURL(t *testing.T) {
+	loc := GeoResult{Latitude: 37.566, Longitude: 126.9784}
+	want := "https://www.openstreetmap.org/?mlat=37.56600&mlon=126.97840#map=12/37.56600/126.97840"
+	if got := locationMapURL(loc); got != want {
+		t.Errorf("locationMapURL = %s, want %s", got, want)
+	}
+}
+
+// 생성이 실패하지 않고, 두 모드 모두 정사각형 격자를 허용된 글자로만 그리는지 본다.
+func TestRenderQRSmoke(t *testing.T) {
+	locs := []GeoResult{
+		{Latitude: 37.566, Longitude: 126.9784},
+		{Latitude: -33.8688, Longitude: -151.2093},
+		{Latitude: 0.00001, Longitude: 0.00001},
+	}
+	for _, loc := range locs {
+		u := locationMapURL(loc)
+		for _, ascii := range []bool{false, true} {
+			s, err := renderQR(u, ascii)
+			if err != nil {
+				t.Fatalf("renderQR(%s, ascii=%v): %v", u, ascii, err)
+			}
+			rows := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+			allowed := "▀▄█ "
+			if ascii {
+				allowed = "# "
+			}
+			width := displayWidth(rows[0])
+			for i, row := range rows {
+				if displayWidth(row) != width {
+					t.Fatalf("ascii=%v: row %d width %d, want %d", ascii, i, displayWidth(row), width)
+				}
+				if strings.Trim(row, allowed) != "" {
+					t.Fatalf("ascii=%v: row %d has unexpected runes: %q", ascii, i, row)
+				}
+			}
+			// 한 모듈은 ascii에서 2칸 x 1줄, 반 블록에서 1칸 x 반 줄이다.
+			modules := len(rows)
+			if !ascii {
+				modules = width
+			} else if width != 2*modules {
+				t.Errorf("ascii: %d rows but %d columns, want square", modules, width)
+			}
+			if modules < 21 {
+				t.Errorf("ascii=%v: %d modules, smaller than a version 1 QR", ascii, modules)
+			}
+		}
+	}
+}
+
+func TestIsTerminalFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Error("a regular file is not a terminal")
+	}
+}
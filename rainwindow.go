@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// ---------- --precip-window ----------
+// 앞으로 몇 시간의 시간별 강수 확률을 훑어 처음 비가 올 것 같은 시각을 알려준다.
+const (
+	defaultPrecipThreshold = 50 // %
+	maxPrecipWindow        = 24
+)
+
+// precipHour는 한 시간의 강수 확률이다.
+type precipHour struct {
+	Time time.Time `json:"time"`
+	Prob float64   `json:"prob"`
+}
+
+// rainOutlook은 --precip-window 한 줄이다.
+//
+//	지금 비: "지금 비가 오고 있어요 (앞으로 6시간 중 4시간 비 예상)"
+//	비 예상: "앞으로 6시간 내 14시경 비 예상 (70%)"
+//	맑음:   "앞으로 6시간 비 소식 없음"
+//
+// 시각은 위치의 현지 시각이다. (forecast timezone=auto)
+func rainOutlook(w Current, window int, threshold float64) string {
+	hours := w.NextPrecip
+	if len(hours) > window {
+		hours = hours[:window]
+	}
+
+	if isRainCode(w.WeatherCode) || (w.Precipitation != nil && *w.Precipitation > 0) {
+		n := 0
+		for _, h := range hours {
+			if h.Prob >= threshold {
+				n++
+			}
+		}
+		return fmt.Sprintf("지금 비가 오고 있어요 (앞으로 %d시간 중 %d시간 비 예상)", window, n)
+	}
+
+	for _, h := range hours {
+		if h.Prob >= threshold {
+			return fmt.Sprintf("앞으로 %d시간 내 %s시경 비 예상 (%.0f%%)", window, h.Time.Format("15"), h.Prob)
+		}
+	}
+	return fmt.Sprintf("앞으로 %d시간 비 소식 없음", window)
+}
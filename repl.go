@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ---------- REPL (--stdin, weather repl) ----------
+// 한 줄에 도시 하나씩 읽어 EOF 또는 quit 까지 차례로 조회한다.
+// --from - 과 달리 한 줄씩 바로 응답하고, HTTP 클라이언트를 모든 조회에 같이 쓴다. (지오코딩 캐시도 공유)
+// 한 도시가 실패해도 에러만 보여주고 다음 줄을 읽는다.
+
+// RunRepl은 in에서 도시를 읽는다. prompt면 (터미널일 때) 매 줄 앞에 "> " 를 stderr에 쓴다.
+func RunRepl(ctx context.Context, in io.Reader, prompt bool, opts Options) error {
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(in)
+	for {
+		if prompt {
+			fmt.Fprint(os.Stderr, "> ")
+		}
+		if !sc.Scan() {
+			break
+		}
+		city := strings.TrimSpace(sc.Text())
+		switch strings.ToLower(city) {
+		case "":
+			continue
+		case "quit", "exit":
+			return nil
+		}
+
+		if err := replQuery(ctx, client, city, opts); err != nil && !errors.Is(err, errTempAlert) {
+			if ctx.Err() != nil {
+				return fmt.Errorf("interrupted at %q: %w", city, ctx.Err())
+			}
+			fmt.Fprintf(os.Stderr, "error: %s: %s\n", city, friendlyError(err))
+		}
+		if !opts.JSON {
+			fmt.Println("")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("stdin read failed: %w", err)
+	}
+	return nil
+}
+
+func replQuery(ctx context.Context, client *http.Client, city string, opts Options) error {
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	r, opts, err := fetchReport(ctx, client, city, opts)
+	if err != nil || opts.DryRun {
+		return opts.deadlineErr(ctx, err)
+	}
+	return emitReport(ctx, client, r, opts)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ---------- search 명령 ----------
+// 날씨 없이 지오코딩 결과만 보여준다. (좌표 확인용)
+const maxSearchLimit = 10
+
+// 항상 maxSearchLimit개를 받아 좌표가 이상한 결과를 버리고, 인구순으로 정렬한 뒤 앞에서 maxResults개만 보여준다.
+func RunSearch(ctx context.Context, query string, maxResults int, opts Options) error {
+	if maxResults < 1 || maxResults > maxSearchLimit {
+		return fmt.Errorf("--max-results must be between 1 and %d", maxSearchLimit)
+	}
+
+	client, err := newHTTPClient(opts)
+	if err != nil {
+		return err
+	}
+
+	gctx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.GeocodeTimeout))
+	defer cancel()
+
+	results, err := searchPlaces(gctx, client, query, opts.lang(), maxSearchLimit)
+	if err != nil {
+		return err
+	}
+	results = validGeoResults(results)
+	if len(results) == 0 {
+		return fmt.Errorf("no valid results for city: %q", query)
+	}
+	rankByPopulation(results)
+	if len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	for i, r := range results {
+		line := fmt.Sprintf("%2d. %s  %.4f, %.4f", i+1, placeLabel(r), r.Latitude, r.Longitude)
+		if r.Population > 0 {
+			line += fmt.Sprintf("  인구 %d", r.Population)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// validGeoResults는 validGeoResult를 통과한 결과만 남긴다. (geocode 경로와 같은 기준)
+func validGeoResults(results []GeoResult) []GeoResult {
+	valid := results[:0]
+	for _, r := range results {
+		if validGeoResult(r) {
+			valid = append(valid, r)
+		}
+	}
+	return valid
+}
+
+// placeLabel은 "이름, 시/도, 국가 (CC)" 형태로 만든다. 빈 값은 건너뛴다.
+func placeLabel(r GeoResult) string {
+	label := r.Name
+	if r.Admin1 != "" && r.Admin1 != r.Name {
+		label += ", " + r.Admin1
+	}
+	if r.Country != "" {
+		label += ", " + r.Country
+	}
+	if r.CountryCode != "" {
+		label += " (" + r.CountryCode + ")"
+	}
+	return label
+}
+
+// shellQuote는 안내 문구의 명령을 그대로 복사해 쓸 수 있게 필요할 때만 작은따옴표로 감싼다.
+// 예: New York → 'New York'. 안에 있는 작은따옴표는 닫고 이스케이프한 뒤 다시 연다.
+func shellQuote(s string) string {
+	safe := s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.,", r)
+	}) < 0
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeSearch는 지오코딩 요청에 results를 그대로 돌려주는 서버로 endpoints.Geocode 를 바꾼다.
+func fakeSearch(t *testing.T, results []GeoResult) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(GeoResponse{Results: results})
+	}))
+	t.Cleanup(srv.Close)
+	orig := endpoints
+	endpoints.Geocode = []string{srv.URL}
+	t.Cleanup(func() { endpoints = orig })
+}
+
+func TestRunSearchSkipsInvalidResults(t *testing.T) {
+	fakeSearch(t, []GeoResult{
+		{ID: 1, Name: "", Latitude: 51.5, Longitude: -0.12, Population: 9000000},
+		{ID: 2, Name: "London", Country: "Atlantis", Latitude: 0, Longitude: 0, Population: 8000000},
+		{ID: 3, Name: "London", Country: "Nowhere", Latitude: 123, Longitude: 10, Population: 7000000},
+		{ID: 4, Name: "London", Country: "Canada", CountryCode: "CA", Latitude: 42.9834, Longitude: -81.233, Population: 346765},
+		{ID: 5, Name: "London", Country: "United Kingdom", CountryCode: "GB", Latitude: 51.5085, Longitude: -0.1257, Population: 7556900},
+	})
+
+	got := captureStdout(t, func() {
+		if err := RunSearch(context.Background(), "london", maxSearchLimit, testOptions()); err != nil {
+			t.Fatal(err)
+		}
+	})
+	const want = ` 1. London, United Kingdom (GB)  51.5085, -0.1257  인구 7556900
+ 2. London, Canada (CA)  42.9834, -81.2330  인구 346765
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunSearchNoValidResults(t *testing.T) {
+	fakeSearch(t, []GeoResult{
+		{ID: 1, Name: "Null Island", Latitude: 0, Longitude: 0},
+		{ID: 2, Name: "Broken", Latitude: 10, Longitude: 200},
+	})
+	var err error
+	captureStdout(t, func() {
+		err = RunSearch(context.Background(), "nowhere", maxSearchLimit, testOptions())
+	})
+	if err == nil {
+		t.Fatal("expected error when every result is invalid")
+	}
+}
+
+// 같은 이름의 다른 지역이 있으면 고른 곳을 알리고, 검색 명령은 복사해 쓸 수 있게 따옴표로 감싼다.
+func TestGeocodeNameTieWarning(t *testing.T) {
+	fakeSearch(t, []GeoResult{
+		{ID: 1, Name: "Portland", Admin1: "Maine", Country: "United States", Latitude: 43.6615, Longitude: -70.2553, Population: 66881},
+		{ID: 2, Name: "Portland", Admin1: "Oregon", Country: "United States", Latitude: 45.5234, Longitude: -122.6762, Population: 632309},
+	})
+
+	var loc GeoResult
+	got := captureStderr(t, func() {
+		var err error
+		loc, err = geocode(context.Background(), http.DefaultClient, "Portland city", "en", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if loc.ID != 2 {
+		t.Errorf("picked %s, want Oregon", placeLabel(loc))
+	}
+	const want = "warning: Portland, Oregon, United States 선택됨 (같은 이름이 여러 곳, weather search 'Portland city' 로 확인)\n"
+	if got != want {
+		t.Errorf("warning = %q, want %q", got, want)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"seoul", "seoul"},
+		{"서울", "서울"},
+		{"Saint-Étienne", "Saint-Étienne"},
+		{"New York", "'New York'"},
+		{"O'Fallon", `'O'\''Fallon'`},
+		{"$HOME", "'$HOME'"},
+		{"", "''"},
+	}
+	for _, c := range cases {
+		if got := shellQuote(c.in); got != c.want {
+			t.Errorf("shellQuote(%q) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+// 같은 이름이면 인구가 많은 곳이 먼저다. 인구가 같으면 API 순서를 유지한다.
+func TestRankByPopulation(t *testing.T) {
+	results := []GeoResult{
+		{ID: 1, Name: "New York", Admin1: "Iowa", Population: 0},
+		{ID: 2, Name: "New York", Admin1: "New York", Population: 8175133},
+		{ID: 3, Name: "New York", Admin1: "Florida", Population: 0},
+		{ID: 4, Name: "New York Mills", Admin1: "Minnesota", Population: 1199},
+		{ID: 5, Name: "New York", Admin1: "Lincolnshire", Population: 1199},
+	}
+	rankByPopulation(results)
+	want := []int{2, 4, 5, 1, 3}
+	for i, id := range want {
+		if results[i].ID != id {
+			t.Errorf("rank %d = %d (%s), want %d", i, results[i].ID, placeLabel(results[i]), id)
+		}
+	}
+}
+
+// --geocode-count 로 후보를 여럿 받으면 API 순서가 아니라 인구순으로 고른다.
+// search 는 --max-results 개만 인구순으로 보여준다.
+func TestGeocodePicksMostPopulous(t *testing.T) {
+	fakeSearch(t, []GeoResult{
+		{ID: 1, Name: "New York", Admin1: "Iowa", Country: "United States", Latitude: 40.85, Longitude: -93.26},
+		{ID: 2, Name: "New York", Admin1: "Florida", Country: "United States", Latitude: 30.84, Longitude: -87.2, Population: 40},
+		{ID: 3, Name: "New York", Admin1: "New York", Country: "United States", Latitude: 40.7143, Longitude: -74.006, Population: 8175133},
+	})
+
+	opts := testOptions()
+	opts.GeocodeCount = 3
+	g, err := newGeocoder(http.DefaultClient, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loc GeoResult
+	captureStderr(t, func() { loc, err = g.Geocode(context.Background(), "New York") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.ID != 3 {
+		t.Errorf("picked %s, want New York, New York", placeLabel(loc))
+	}
+
+	got := captureStdout(t, func() {
+		if err := RunSearch(context.Background(), "New York", 2, testOptions()); err != nil {
+			t.Fatal(err)
+		}
+	})
+	const want = ` 1. New York, United States  40.7143, -74.0060  인구 8175133
+ 2. New York, Florida, United States  30.8400, -87.2000  인구 40
+`
+	if got != want {
+		t.Errorf("search got:\n%s\nwant:\n%s", got, want)
+	}
+}
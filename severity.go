@@ -0,0 +1,20 @@
+package main
+
+// ---------- Condition severity ----------
+// codeSeverity는 WMO weather code를 심각도 순서로 바꾼다.
+// 맑음 < 흐림 < 안개 < 이슬비 < 비 < 어는 비 < 눈 < 뇌우 (같은 종류는 강할수록 크다)
+// 모르는 코드는 -1 이다.
+func codeSeverity(code int) int {
+	return lookupCode(code).severity
+}
+
+// worstCode는 여러 시각의 코드 중 가장 심한 것을 고른다. 비어 있으면 -1 이다.
+func worstCode(codes []int) int {
+	worst := -1
+	for _, c := range codes {
+		if worst == -1 || codeSeverity(c) > codeSeverity(worst) {
+			worst = c
+		}
+	}
+	return worst
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// ---------- JSON snapshots ----------
+// --save-json <dir> 은 조회마다 SummaryJSON 전체를 <dir>/<city>-<timestamp>.json 으로 남긴다.
+// (개인 시계열 기록용, 없는 폴더는 만든다)
+
+// saveSnapshot은 쓴 파일 경로를 돌려준다.
+func saveSnapshot(dir string, r Report, opts Options) (string, error) {
+	b, err := json.MarshalIndent(newSummaryJSON(r, opts), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("snapshot encode failed: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("snapshot dir create failed (%s): %w", dir, err)
+	}
+	name := fmt.Sprintf("%s-%s.json", safeFileName(r.Location.Name), r.Time.Format("20060102T150405"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("snapshot write failed (%s): %w", path, err)
+	}
+	return path, nil
+}
+
+// safeFileName은 글자와 숫자만 남기고 나머지는 - 로 바꾼다. ("New York" → "new-york")
+// 한글 등 글자는 그대로 둔다.
+func safeFileName(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	name := strings.TrimSuffix(b.String(), "-")
+	if name == "" {
+		return "location"
+	}
+	return name
+}
@@ -0,0 +1,35 @@
+package main
+
+import "math"
+
+// ---------- Temperature comparison ----------
+// 부동소수점 오차(0.0000001 vs 0) 때문에 경계값 비교가 흔들리지 않도록
+// 기준과의 비교는 이 헬퍼를 쓴다. 단위는 섞지 않는다. (호출하는 쪽이 맞춘다)
+
+// tempEpsilon은 같은 온도로 보는 허용 오차다.
+const tempEpsilon = 1e-6
+
+type Temperature float64
+
+// Equal은 두 온도의 차이가 epsilon 이하인지 본다.
+func (t Temperature) Equal(other Temperature, epsilon float64) bool {
+	return math.Abs(float64(t-other)) <= epsilon
+}
+
+// AtMost는 t <= limit 이다. (경계는 tempEpsilon 안이면 같다고 본다)
+func (t Temperature) AtMost(limit Temperature) bool {
+	return t < limit || t.Equal(limit, tempEpsilon)
+}
+
+// AtLeast는 t >= limit 이다.
+func (t Temperature) AtLeast(limit Temperature) bool {
+	return t > limit || t.Equal(limit, tempEpsilon)
+}
+
+// celsius는 --unit 단위의 값 v를 °C로 바꾼다. (섭씨 기준 상수와 비교할 때)
+func (o Options) celsius(v float64) Temperature {
+	if o.imperial() {
+		return Temperature(fahrenheitToCelsius(v))
+	}
+	return Temperature(v)
+}
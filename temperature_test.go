@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestTemperatureBoundaries(t *testing.T) {
+	cases := []struct {
+		t, limit               Temperature
+		equal, atMost, atLeast bool
+	}{
+		{0, 0, true, true, true},
+		{0.0000001, 0, true, true, true},
+		{-0.0000001, 0, true, true, true},
+		{0.1 + 0.2 - 0.3, 0, true, true, true}, // 5.5e-17
+		{0.001, 0, false, false, true},
+		{-0.001, 0, false, true, false},
+		{35, 35, true, true, true},
+		{34.9999999, 35, true, true, true},
+		{35.0000001, 35, true, true, true},
+		{34.99, 35, false, true, false},
+		{35.01, 35, false, false, true},
+	}
+	for _, c := range cases {
+		if got := c.t.Equal(c.limit, tempEpsilon); got != c.equal {
+			t.Errorf("%v.Equal(%v) = %v, want %v", c.t, c.limit, got, c.equal)
+		}
+		if got := c.t.AtMost(c.limit); got != c.atMost {
+			t.Errorf("%v.AtMost(%v) = %v, want %v", c.t, c.limit, got, c.atMost)
+		}
+		if got := c.t.AtLeast(c.limit); got != c.atLeast {
+			t.Errorf("%v.AtLeast(%v) = %v, want %v", c.t, c.limit, got, c.atLeast)
+		}
+	}
+}
+
+// 화씨 경계 (32°F, 95°F)도 °C로 바꾼 뒤 0.0°C, 35.0°C 와 같다고 본다.
+func TestCelsiusBoundaries(t *testing.T) {
+	f := testOptions()
+	f.Unit = "f"
+	cases := []struct {
+		opts  Options
+		v     float64
+		limit Temperature
+	}{
+		{testOptions(), 0, 0},
+		{testOptions(), 35, 35},
+		{f, 32, 0},
+		{f, 95, 35},
+	}
+	for _, c := range cases {
+		if got := c.opts.celsius(c.v); !got.Equal(c.limit, tempEpsilon) {
+			t.Errorf("celsius(%v %s) = %v, want %v", c.v, c.opts.Unit, got, c.limit)
+		}
+	}
+}
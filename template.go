@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// ---------- --format template ----------
+// --format 은 SummaryJSON의 JSON 키로 접근하는 text/template이다.
+// 예: --format '{{.city}} {{.weather.temperature}}°C {{.air.pm10_grade}}'
+const (
+	fieldMissingError = "error" // 없는 필드를 참조하면 에러 (기본)
+	fieldMissingEmpty = "empty" // 빈 문자열로 출력
+)
+
+// text/template이 없는 map 키를 출력할 때 쓰는 문자열
+const templateNoValue = "<no value>"
+
+var missingFieldRe = regexp.MustCompile(`at <(\.[^>]*)>: map has no entry`)
+
+// loadFormatFile은 --format-file 의 템플릿을 읽는다. 끝의 줄바꿈은 출력 때 한 줄로 정리된다.
+func loadFormatFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("format file read failed: %w", err)
+	}
+	return string(b), nil
+}
+
+// parseFormat은 템플릿을 한 번만 파싱한다. source는 에러에 보일 출처다. (--format, --format-file <path>)
+func parseFormat(text, source, fieldMissing string) (*template.Template, error) {
+	missing := "missingkey=error"
+	if fieldMissing == fieldMissingEmpty {
+		missing = "missingkey=default"
+	}
+	t, err := template.New("format").Option(missing).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", source, err)
+	}
+	return t, nil
+}
+
+// templateData는 SummaryJSON을 JSON 키 기준 map으로 바꾼다.
+// 포인터 필드는 값으로 풀리고, null은 빈 문자열이 된다.
+// 빠진 섹션(weather, air 등)은 빈 map으로 두어 {{.weather.temperature}}가 키 누락으로 보고되게 한다.
+func templateData(r Report, opts Options) (map[string]any, error) {
+	b, err := json.Marshal(newSummaryJSON(r, opts))
+	if err != nil {
+		return nil, fmt.Errorf("format encode failed: %w", err)
+	}
+	var data map[string]any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("format decode failed: %w", err)
+	}
+	blankNulls(data)
+	for _, k := range []string{"weather", "air", "vs_normal"} {
+		if _, ok := data[k]; !ok {
+			data[k] = map[string]any{}
+		}
+	}
+	return data, nil
+}
+
+func blankNulls(m map[string]any) {
+	for k, v := range m {
+		switch v := v.(type) {
+		case nil:
+			m[k] = ""
+		case map[string]any:
+			blankNulls(v)
+		}
+	}
+}
+
+// templateOutputter는 Report마다 미리 파싱한 --format 템플릿을 한 줄로 출력한다.
+type templateOutputter struct {
+	opts Options
+	tmpl *template.Template
+}
+
+func (o templateOutputter) Output(r Report) error {
+	data, err := templateData(r, o.opts)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := o.tmpl.Execute(&buf, data); err != nil {
+		if m := missingFieldRe.FindStringSubmatch(err.Error()); m != nil {
+			return fmt.Errorf("%s field %s is missing in this result (use --field-missing=empty to print blanks)", o.opts.FormatSource, m[1])
+		}
+		return fmt.Errorf("format failed (%s): %w", o.opts.FormatSource, err)
+	}
+
+	out := buf.String()
+	if o.opts.FieldMissing == fieldMissingEmpty {
+		out = strings.ReplaceAll(out, templateNoValue, "")
+	}
+	_, err = fmt.Fprintln(os.Stdout, strings.TrimRight(out, "\n"))
+	return err
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func formatOutput(t *testing.T, r Report, text, source, fieldMissing string) (string, error) {
+	t.Helper()
+	opts := testOptions()
+	opts.Format, opts.FormatSource, opts.FieldMissing = text, source, fieldMissing
+	tmpl, err := parseFormat(text, source, fieldMissing)
+	if err != nil {
+		return "", err
+	}
+	opts.Template = tmpl
+
+	var outErr error
+	out := captureStdout(t, func() { outErr = newOutputter(opts).Output(r) })
+	return out, outErr
+}
+
+func TestFormatMissingField(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	r := testReport()
+	r.WeatherErr = errors.New("forecast down") // weather 섹션이 빠진다.
+
+	_, err := formatOutput(t, r, "{{.city}} {{.weather.temperature}}", "--format", fieldMissingError)
+	if err == nil {
+		t.Fatal("template with an absent field succeeded")
+	}
+	for _, want := range []string{"--format", ".weather.temperature", "--field-missing=empty"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	out, err := formatOutput(t, r, "{{.city}} [{{.weather.temperature}}]", "--format", fieldMissingEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "서울 []\n" {
+		t.Errorf("--field-missing=empty output = %q, want %q", out, "서울 []\n")
+	}
+}
+
+// 포인터 필드는 주소가 아니라 값으로, null은 빈 칸으로 나와야 한다.
+func TestFormatPointerFields(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	r := testReport()
+	r.Weather.PrecipProbability = nil // JSON null
+
+	out, err := formatOutput(t, r, "{{.air.us_aqi}}|{{.weather.visibility_m}}|{{.weather.precipitation_probability}}|", "--format", fieldMissingError)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "72|24000||\n" {
+		t.Errorf("output = %q, want %q", out, "72|24000||\n")
+	}
+}
+
+func TestFormatErrorNamesSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.tmpl")
+	if err := os.WriteFile(path, []byte("{{.city"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	text, err := loadFormatFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parseFormat(text, "--format-file "+path, fieldMissingError)
+	if err == nil || !strings.Contains(err.Error(), "--format-file "+path) {
+		t.Errorf("err = %v, want it to name --format-file %s", err, path)
+	}
+}
+
+// --format-file 은 파일의 템플릿으로 리포트를 렌더링한다. 끝의 줄바꿈은 한 줄로 정리된다.
+func TestFormatFileRender(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	cases := []struct {
+		name, text, want string
+	}{
+		{"one line", "{{.city}} {{.air.us_aqi}}", "서울 72\n"},
+		{"trailing newline", "{{.city}} {{.air.us_aqi}}\n", "서울 72\n"},
+		{"multi line", "{{.city}}\nAQI {{.air.us_aqi}}\n\n", "서울\nAQI 72\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "summary.tmpl")
+			if err := os.WriteFile(path, []byte(c.text), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			text, err := loadFormatFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := formatOutput(t, testReport(), text, "--format-file "+path, fieldMissingError)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out != c.want {
+				t.Errorf("output = %q, want %q", out, c.want)
+			}
+		})
+	}
+}
+
+func TestFormatFileMissing(t *testing.T) {
+	_, err := loadFormatFile(filepath.Join(t.TempDir(), "nope.tmpl"))
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("err = %v, want a not-exist read error", err)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowPath는 srv에서 path 요청만 요청 ctx가 끝날 때까지 (최대 5초) 붙잡아 두는 핸들러로 바꾼다.
+func slowPath(t *testing.T, srv *httptest.Server, path string) {
+	t.Helper()
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) }) // srv.Close 보다 먼저 풀어 준다.
+	next := srv.Config.Handler
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == path {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+const friendlyTimeout = "응답 시간이 초과되었습니다"
+
+// 엔드포인트별 타임아웃은 그 호출만 끊고, 안내 문구는 타임아웃으로 나온다.
+func TestEndpointTimeouts(t *testing.T) {
+	cases := []struct {
+		name string
+		slow string
+		set  func(*Options)
+	}{
+		{"geocode", "/v1/search", func(o *Options) { o.GeocodeTimeout = 50 * time.Millisecond }},
+		{"forecast", "/v1/forecast", func(o *Options) { o.ForecastTimeout = 50 * time.Millisecond }},
+		{"air", "/v1/air-quality", func(o *Options) { o.AirTimeout = 50 * time.Millisecond }},
+		{"global", "/v1/forecast", func(o *Options) { o.Timeout = 50 * time.Millisecond }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			srv := fakeOpenMeteo(t, map[string]GeoResult{"seoul": {ID: 1, Name: "서울", Latitude: 37.566, Longitude: 126.9784}})
+			slowPath(t, srv, c.slow)
+			opts := testOptions()
+			opts.Timeout = 5 * time.Second
+			c.set(&opts)
+
+			start := time.Now()
+			_, _, err := fetchReport(context.Background(), srv.Client(), "seoul", opts)
+			if err == nil {
+				t.Fatal("expected timeout error")
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("took %s, timeout did not fire", elapsed)
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("err = %v, want context.DeadlineExceeded in chain", err)
+			}
+			if got := friendlyError(err); !strings.HasPrefix(got, friendlyTimeout) {
+				t.Errorf("friendlyError = %q, want timeout message", got)
+			}
+		})
+	}
+}
+
+// --best-effort 면 대기질만 타임아웃되고 날씨는 그대로 받는다.
+func TestAirTimeoutKeepsWeather(t *testing.T) {
+	srv := fakeOpenMeteo(t, map[string]GeoResult{"seoul": {ID: 1, Name: "서울", Latitude: 37.566, Longitude: 126.9784}})
+	slowPath(t, srv, "/v1/air-quality")
+	opts := testOptions()
+	opts.BestEffort = true
+	opts.AirTimeout = 50 * time.Millisecond
+
+	res, err := fetchAll(context.Background(), srv.Client(), GeoResult{Latitude: 37.566, Longitude: 126.9784}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.WeatherErr != nil || res.Weather.Temperature2m != 12.3 {
+		t.Errorf("weather = %+v, %v; want it fetched", res.Weather, res.WeatherErr)
+	}
+	if !errors.Is(res.AirErr, context.DeadlineExceeded) {
+		t.Errorf("air err = %v, want timeout", res.AirErr)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ---------- Extra time zones ----------
+// parseZones는 --also-tz 의 쉼표 목록을 time.LoadLocation 으로 검사한다.
+func parseZones(s string) ([]*time.Location, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var zones []*time.Location
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --also-tz %q: %w", name, err)
+		}
+		zones = append(zones, loc)
+	}
+	return zones, nil
+}
+
+// zoneLines는 헤더 아래 "  America/New_York 10-13 20:04 (EDT)" 줄들이다.
+func zoneLines(now time.Time, zones []*time.Location) []string {
+	lines := make([]string, 0, len(zones))
+	for _, z := range zones {
+		lines = append(lines, fmt.Sprintf("  %s %s", z, now.In(z).Format("01-02 15:04 (MST)")))
+	}
+	return lines
+}
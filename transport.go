@@ -0,0 +1,124 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ---------- HTTP client ----------
+// 인증서 고정(--pin)은 이 도메인(및 하위 도메인)에만 적용한다.
+var pinnedHostSuffix = "open-meteo.com"
+
+var errCertPinMismatch = errors.New("certificate fingerprint does not match --pin")
+
+// newHTTPClient는 옵션에 따라 TLS 설정을 조정한 클라이언트를 만든다.
+// 기본은 시스템 기본 transport를 그대로 쓴다.
+func newHTTPClient(opts Options) (*http.Client, error) {
+	tr, err := newTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: requestIDTransport{base: tr, id: opts.RequestID, verbose: opts.Verbose}}, nil
+}
+
+func newTransport(opts Options) (http.RoundTripper, error) {
+	if !opts.StrictHTTPS && len(opts.Pins) == 0 {
+		return http.DefaultTransport, nil
+	}
+
+	pins := make(map[string]bool, len(opts.Pins))
+	for _, p := range opts.Pins {
+		fp, err := normalizeFingerprint(p)
+		if err != nil {
+			return nil, err
+		}
+		pins[fp] = true
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(pins) == 0 || !pinnedHost(cs.ServerName) {
+				return nil
+			}
+			if len(cs.PeerCertificates) == 0 {
+				return errCertPinMismatch
+			}
+			sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+			if !pins[hex.EncodeToString(sum[:])] {
+				return fmt.Errorf("%w (host %s)", errCertPinMismatch, cs.ServerName)
+			}
+			return nil
+		},
+	}
+	return tr, nil
+}
+
+// requestIDTransport는 모든 요청에 같은 X-Request-ID를 붙인다. (프록시/지원 문의 추적용)
+// 응답의 Date, X-* 헤더는 요청 ctx의 responseMeta에 남긴다.
+type requestIDTransport struct {
+	base    http.RoundTripper
+	id      string
+	verbose bool
+}
+
+func (t requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.id != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("X-Request-ID", t.id)
+	}
+	if t.verbose {
+		fmt.Fprintf(os.Stderr, "debug: %s %s\n", req.Method, req.URL)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		e := newAPIMeta(resp)
+		if t.verbose {
+			fmt.Fprintf(os.Stderr, "debug: response %d %s%s\n", resp.StatusCode, e.Endpoint, e.headerText())
+		}
+		responseMetaFrom(req.Context()).record(e)
+	}
+	return resp, err
+}
+
+// newRequestID는 랜덤 UUID (v4)를 만든다.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func pinnedHost(host string) bool {
+	return host == pinnedHostSuffix || strings.HasSuffix(host, "."+pinnedHostSuffix)
+}
+
+// normalizeFingerprint는 "AB:CD:..." 또는 "abcd..." 형태의 SHA-256 지문을 소문자 hex로 맞춘다.
+func normalizeFingerprint(s string) (string, error) {
+	fp := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(s, "sha256:"), ":", ""))
+	if b, err := hex.DecodeString(fp); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid --pin %q (expected SHA-256 hex fingerprint)", s)
+	}
+	return fp, nil
+}
+
+// stringList는 반복 가능한 문자열 flag다. (--pin a --pin b)
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
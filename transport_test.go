@@ -0,0 +1,94 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pinClient는 --pin 을 건 transport로 srv에 붙는 클라이언트다.
+// httptest 인증서는 example.com 용이므로 그 이름을 고정 대상 도메인으로 쓴다.
+func pinClient(t *testing.T, srv *httptest.Server, pins ...string) *http.Client {
+	t.Helper()
+	orig := pinnedHostSuffix
+	pinnedHostSuffix = "example.com"
+	t.Cleanup(func() { pinnedHostSuffix = orig })
+
+	rt, err := newTransport(Options{StrictHTTPS: true, Pins: pins})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := rt.(*http.Transport)
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	tr.TLSClientConfig.RootCAs = roots
+	tr.TLSClientConfig.ServerName = "example.com"
+	return &http.Client{Transport: tr}
+}
+
+// newTLSServer는 거절된 핸드셰이크 로그를 버리는 TLS 테스트 서버다.
+func newTLSServer() *httptest.Server {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	srv.StartTLS()
+	return srv
+}
+
+func fingerprint(srv *httptest.Server) string {
+	sum := sha256.Sum256(srv.Certificate().Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPinMatch(t *testing.T) {
+	srv := newTLSServer()
+	defer srv.Close()
+
+	// 콜론 구분 대문자 형식도 받아야 한다.
+	fp := strings.ToUpper(fingerprint(srv))
+	var colon []string
+	for i := 0; i < len(fp); i += 2 {
+		colon = append(colon, fp[i:i+2])
+	}
+
+	resp, err := pinClient(t, srv, strings.Join(colon, ":")).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("matching pin rejected: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestPinMismatch(t *testing.T) {
+	srv := newTLSServer()
+	defer srv.Close()
+
+	_, err := pinClient(t, srv, strings.Repeat("00", sha256.Size)).Get(srv.URL)
+	if !errors.Is(err, errCertPinMismatch) {
+		t.Fatalf("err = %v, want errCertPinMismatch", err)
+	}
+}
+
+func TestNormalizeFingerprint(t *testing.T) {
+	valid := strings.Repeat("ab", sha256.Size)
+	for _, in := range []string{valid, strings.ToUpper(valid), "sha256:" + valid} {
+		got, err := normalizeFingerprint(in)
+		if err != nil || got != valid {
+			t.Errorf("normalizeFingerprint(%q) = %q, %v; want %q", in, got, err, valid)
+		}
+	}
+
+	for _, in := range []string{"", "xyz", strings.Repeat("ab", sha256.Size-1), strings.Repeat("zz", sha256.Size)} {
+		if _, err := normalizeFingerprint(in); err == nil {
+			t.Errorf("normalizeFingerprint(%q) succeeded, want error", in)
+		}
+	}
+	if _, err := newTransport(Options{Pins: []string{"not-hex"}}); err == nil {
+		t.Error("newTransport accepted a malformed --pin")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import "fmt"
+
+// ---------- Temperature trend ----------
+// 최근 trendHours시간의 정시 기온 기울기로 오르는지 내리는지 본다.
+const (
+	trendHours = 3
+
+	// 시간당 이 이상 변해야 화살표를 올리거나 내린다. (°C/h)
+	trendThreshold = 0.3
+
+	// --past-hours 최대값
+	maxPastHours = 24
+)
+
+// tempTrend는 오래된 순 기온(°C)의 시간당 평균 기울기로 ↑, ↓, → 를 돌려준다.
+// 값이 2개 미만이면 "" 이다.
+func tempTrend(hourlyTemps []float64) string {
+	n := len(hourlyTemps)
+	if n < 2 {
+		return ""
+	}
+	slope := (hourlyTemps[n-1] - hourlyTemps[0]) / float64(n-1)
+	switch s := Temperature(slope); {
+	case s.AtLeast(trendThreshold):
+		return "↑"
+	case s.AtMost(-trendThreshold):
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// trendText는 단위와 아이콘 세트를 맞춰 화살표를 돌려준다.
+func trendText(w Current, opts Options) string {
+	temps := w.recentTemps(trendHours)
+	if opts.imperial() {
+		for i, t := range temps {
+			temps[i] = fahrenheitToCelsius(t)
+		}
+	}
+
+	arrow := tempTrend(temps)
+	if !opts.ascii() {
+		return arrow
+	}
+	switch arrow {
+	case "↑":
+		return "^"
+	case "↓":
+		return "v"
+	case "→":
+		return "="
+	}
+	return arrow
+}
+
+// comfortLabel은 체감 온도(°C)의 쾌적도다.
+func comfortLabel(apparent float64) string {
+	switch {
+	case apparent < 5:
+		return "추움"
+	case apparent < 12:
+		return "쌀쌀"
+	case apparent < 24:
+		return "쾌적"
+	case apparent < 29:
+		return "더움"
+	default:
+		return "무더움"
+	}
+}
+
+// apparentCelsius는 단위와 관계없이 체감 온도를 °C로 돌려준다.
+func (c Current) apparentCelsius(opts Options) float64 {
+	return float64(opts.celsius(c.ApparentTemperature))
+}
+
+// pastRecap은 --past-hours 요약이다. 예: " (3시간 전 8°C → 현재 12°C)"
+// 그 시각 기록이 없으면 (자정 직후 결측 등) "" 이다.
+func pastRecap(w Current, opts Options) string {
+	if opts.PastHours <= 0 {
+		return ""
+	}
+	past, ok := w.tempHoursAgo(opts.PastHours)
+	if !ok {
+		return ""
+	}
+	p, unit := opts.Precision, tempSymbol(opts)
+	return fmt.Sprintf(" (%d시간 전 %s%s → 현재 %s%s)",
+		opts.PastHours, p.format("temp", past), unit, p.format("temp", w.Temperature2m), unit)
+}
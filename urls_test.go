@@ -0,0 +1,79 @@
+package main
+
+import "testing"
+
+// 쿼리 파라미터는 키 이름순으로, 값은 URL 인코딩해서 붙는다. (쉼표 → %2C, 슬래시 → %2F)
+func TestBuildForecastURL(t *testing.T) {
+	imperial := testOptions()
+	imperial.Unit = "f"
+	window := testOptions()
+	window.PrecipWindow = 3
+
+	cases := []struct {
+		name     string
+		lat, lon float64
+		q        queryOpts
+		want     string
+	}{
+		{
+			"minimal", 37.566, 126.9784, queryOpts{},
+			"?latitude=37.566&longitude=126.9784",
+		},
+		{
+			"negative coords", -33.8688, -151.2093, queryOpts{Current: []string{"temperature_2m"}},
+			"?current=temperature_2m&latitude=-33.8688&longitude=-151.2093",
+		},
+		{
+			"escaped lists and timezone", 40.7128, -74.006,
+			queryOpts{Timezone: "America/New_York", Current: []string{"temperature_2m", "weather_code"}, Daily: []string{"sunrise", "sunset"}, Days: 2},
+			"?current=temperature_2m%2Cweather_code&daily=sunrise%2Csunset&forecast_days=2&latitude=40.7128&longitude=-74.006&timezone=America%2FNew_York",
+		},
+		{
+			"archive range", 37.566, 126.9784,
+			queryOpts{Timezone: "Asia/Seoul", Daily: []string{"temperature_2m_mean"}, StartDate: "2024-03-14", EndDate: "2024-03-14"},
+			"?daily=temperature_2m_mean&end_date=2024-03-14&latitude=37.566&longitude=126.9784&start_date=2024-03-14&timezone=Asia%2FSeoul",
+		},
+		{
+			"default query", 37.566, 126.9784, forecastQuery(testOptions()),
+			"?current=temperature_2m%2Capparent_temperature%2Cprecipitation_probability%2Cprecipitation%2Cweather_code%2Cvisibility%2Cuv_index%2Cwind_speed_10m%2Crelative_humidity_2m" +
+				"&daily=sunrise%2Csunset%2Ctemperature_2m_max%2Ctemperature_2m_min&forecast_days=1&forecast_hours=1&hourly=temperature_2m" +
+				"&latitude=37.566&longitude=126.9784&past_hours=3&timezone=auto",
+		},
+		{
+			"imperial units", 37.566, 126.9784, queryOpts{TemperatureUnit: forecastQuery(imperial).TemperatureUnit, WindSpeedUnit: forecastQuery(imperial).WindSpeedUnit},
+			"?latitude=37.566&longitude=126.9784&temperature_unit=fahrenheit&wind_speed_unit=mph",
+		},
+		{
+			"precip window", 37.566, 126.9784, queryOpts{Hourly: forecastQuery(window).Hourly, ForecastHours: forecastQuery(window).ForecastHours},
+			"?forecast_hours=4&hourly=temperature_2m%2Cprecipitation_probability&latitude=37.566&longitude=126.9784",
+		},
+	}
+	for _, c := range cases {
+		if got, want := buildForecastURL(forecastBaseURL, c.lat, c.lon, c.q), forecastBaseURL+c.want; got != want {
+			t.Errorf("%s:\n got %s\nwant %s", c.name, got, want)
+		}
+	}
+}
+
+func TestBuildAirURL(t *testing.T) {
+	eu := testOptions()
+	eu.AQIStandard = aqiStandardEU
+	avg := testOptions()
+	avg.PM25Avg = true
+
+	cases := []struct {
+		name string
+		q    queryOpts
+		want string
+	}{
+		{"us", airQuery(testOptions(), false), "?current=pm10%2Cpm2_5%2Cus_aqi&latitude=37.566&longitude=126.9784&timezone=Asia%2FSeoul"},
+		{"eu", airQuery(eu, false), "?current=pm10%2Cpm2_5%2Cus_aqi%2Ceuropean_aqi&latitude=37.566&longitude=126.9784&timezone=Asia%2FSeoul"},
+		{"gases", airQuery(testOptions(), true), "?current=pm10%2Cpm2_5%2Cus_aqi%2Cozone%2Cnitrogen_dioxide%2Csulphur_dioxide%2Ccarbon_monoxide&latitude=37.566&longitude=126.9784&timezone=Asia%2FSeoul"},
+		{"pm25 average", airQuery(avg, false), "?current=pm10%2Cpm2_5%2Cus_aqi&forecast_hours=1&hourly=pm2_5&latitude=37.566&longitude=126.9784&past_hours=23&timezone=Asia%2FSeoul"},
+	}
+	for _, c := range cases {
+		if got, want := buildAirURL(airBaseURL, 37.566, 126.9784, c.q), airBaseURL+c.want; got != want {
+			t.Errorf("%s:\n got %s\nwant %s", c.name, got, want)
+		}
+	}
+}
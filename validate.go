@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// ---------- Validate ----------
+// 기온 허용 범위. Report에는 단위가 없으므로 °C/°F 어느 쪽으로 읽어도 지구 기록 밖인 값만 잡는다.
+// (-999 같은 결측 표시값을 걸러 내려는 것)
+const (
+	plausibleTempMin = -130
+	plausibleTempMax = 140
+)
+
+// Validate는 믿기 어려운 값에 대한 경고 목록을 돌려준다. 없으면 nil이다.
+// 실패한 섹션 (WeatherErr, AirErr)은 보지 않는다.
+func (r Report) Validate() []string {
+	var warns []string
+	add := func(format string, args ...any) {
+		warns = append(warns, fmt.Sprintf(format, args...))
+	}
+
+	loc := r.Location
+	if loc.Latitude < -90 || loc.Latitude > 90 || loc.Longitude < -180 || loc.Longitude > 180 {
+		add("coordinates (%g, %g) out of range", loc.Latitude, loc.Longitude)
+	}
+
+	if r.WeatherErr == nil {
+		w := r.Weather
+		for _, t := range []struct {
+			name string
+			v    float64
+		}{
+			{"temperature", w.Temperature2m},
+			{"apparent temperature", w.ApparentTemperature},
+		} {
+			if t.v < plausibleTempMin || t.v > plausibleTempMax {
+				add("%s %g is implausible", t.name, t.v)
+			}
+		}
+		if v := w.PrecipProbability; v != nil && (*v < 0 || *v > 100) {
+			add("precipitation probability %g%% out of range", *v)
+		}
+		if w.Humidity != nil && (*w.Humidity < 0 || *w.Humidity > 100) {
+			add("humidity %g%% out of range", *w.Humidity)
+		}
+		for _, v := range []struct {
+			name string
+			v    *float64
+		}{
+			{"visibility", w.Visibility},
+			{"uv index", w.UVIndex},
+			{"precipitation", w.Precipitation},
+			{"wind speed", w.WindSpeed},
+		} {
+			if v.v != nil && *v.v < 0 {
+				add("%s %g is negative", v.name, *v.v)
+			}
+		}
+	}
+
+	if r.AirErr == nil {
+		a := r.Air
+		if a.PM10 < 0 {
+			add("pm10 %g is negative", a.PM10)
+		}
+		if a.PM25 < 0 {
+			add("pm2_5 %g is negative", a.PM25)
+		}
+		if a.AQIUS != nil && *a.AQIUS < 0 {
+			add("us_aqi %d is negative", *a.AQIUS)
+		}
+		if a.AQIEU != nil && *a.AQIEU < 0 {
+			add("european_aqi %d is negative", *a.AQIEU)
+		}
+	}
+	return warns
+}
+
+// reportValidation은 --validate 경고를 stderr에 쓴다.
+// 경고가 있고 --exit-on-warning 이면 에러를 돌려준다.
+func reportValidation(r Report, opts Options) error {
+	warns := r.Validate()
+	for _, w := range warns {
+		fmt.Fprintf(os.Stderr, "warning: validate: %s\n", w)
+	}
+	if len(warns) > 0 && opts.ExitOnWarning {
+		return fmt.Errorf("validation found %d implausible value(s)", len(warns))
+	}
+	return nil
+}
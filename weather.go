@@ -1,13 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
-	"sync"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // ---------- Open-Meteo: Geocoding ----------
@@ -16,179 +26,1086 @@ type GeoResponse struct {
 }
 
 type GeoResult struct {
-	Name      string  `json:"name"`
-	Country   string  `json:"country"`
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Country     string `json:"country"`
+	CountryCode string `json:"country_code"`
+	Admin1      string `json:"admin1"` // 시/도, 주
+	Population  int    `json:"population"`
+
+	// Query는 이름을 영어 결과로 대체했을 때의 원래 검색어다.
+	Query string `json:"query,omitempty"`
+
+	// Approximate는 IP 기반 추정 위치일 때 true다.
+	Approximate bool `json:"-"`
+	// Rounded는 --round-coordinates-privacy 로 좌표를 반올림한 간격(°)이다. (0이면 원래 좌표)
+	Rounded   float64 `json:"-"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
 
 // ---------- Open-Meteo: Weather ----------
 type OpenMeteoResponse struct {
-	Current Current `json:"current"`
+	Current *Current `json:"current"` // 점검 중에는 null로 올 수 있다.
+
+	// timezone=auto 면 위치의 시간대다. daily/hourly 시각은 이 시간대의 현지 시각이다.
+	Timezone         string `json:"timezone"`
+	TimezoneAbbr     string `json:"timezone_abbreviation"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+
+	Daily struct {
+		Sunrise []string   `json:"sunrise"` // 현지 시각 "2006-01-02T15:04"
+		Sunset  []string   `json:"sunset"`
+		TempMax []*float64 `json:"temperature_2m_max"`
+		TempMin []*float64 `json:"temperature_2m_min"`
+	} `json:"daily"`
+	Hourly struct {
+		Time        []string   `json:"time"`
+		Temperature []*float64 `json:"temperature_2m"`            // 과거 max(trendHours, --past-hours)시간 ~ 현재 (~ --precip-window)
+		PrecipProb  []*float64 `json:"precipitation_probability"` // --precip-window 일 때만
+	} `json:"hourly"`
 }
 
 type Current struct {
-	Temperature2m       float64 `json:"temperature_2m"`
-	ApparentTemperature float64 `json:"apparent_temperature"`
-	PrecipProbability   int     `json:"precipitation_probability"`
-	WeatherCode         int     `json:"weather_code"`
+	Temperature2m       float64  `json:"temperature_2m"`
+	ApparentTemperature float64  `json:"apparent_temperature"`
+	PrecipProbability   *float64 `json:"precipitation_probability"` // API는 보통 정수 %지만 소수도 받는다. 모델에 따라 null
+	WeatherCode         int      `json:"weather_code"`
+
+	// Visibility는 미터 단위이며 모델에 따라 null일 수 있다.
+	Visibility *float64 `json:"visibility"`
+
+	UVIndex *float64 `json:"uv_index"`
+
+	// Precipitation은 직전 1시간 강수량 (mm, 단위 설정과 관계없이 mm로 받는다)
+	Precipitation *float64 `json:"precipitation"`
+
+	Humidity *float64 `json:"relative_humidity_2m"` // %
+
+	// WindSpeed는 10m 풍속 (km/h, --unit=f 면 mph)
+	WindSpeed *float64 `json:"wind_speed_10m"`
+
+	// 오늘 일출/일몰 (daily 응답에서 채운다, 없으면 zero)
+	Sunrise time.Time `json:"sunrise,omitzero"`
+	Sunset  time.Time `json:"sunset,omitzero"`
+
+	// NextSunrise는 내일 일출이다. (--since-sunrise 로 이틀치를 받을 때만)
+	NextSunrise time.Time `json:"next_sunrise,omitzero"`
+
+	// 오늘 예보 최고/최저 (없으면 nil)
+	TodayMax *float64 `json:"today_max,omitempty"`
+	TodayMin *float64 `json:"today_min,omitempty"`
+
+	// PastTemps는 몇 시간 전 ~ 현재 시각의 정시 기온이다. (오래된 순, 결측은 nil)
+	PastTemps []*float64 `json:"past_temps,omitempty"`
+
+	// NextPrecip은 다음 시각부터의 시간별 강수 확률이다. (--precip-window 일 때만)
+	NextPrecip []precipHour `json:"next_precip,omitempty"`
+}
+
+// recentTemps는 최근 hours시간 ~ 현재의 정시 기온이다. (결측 제외)
+func (c Current) recentTemps(hours int) []float64 {
+	ts := c.PastTemps
+	if len(ts) > hours+1 {
+		ts = ts[len(ts)-hours-1:]
+	}
+	var out []float64
+	for _, t := range ts {
+		if t != nil {
+			out = append(out, *t)
+		}
+	}
+	return out
 }
 
+// tempHoursAgo는 hours시간 전 정시 기온이다. 범위 밖이거나 결측이면 false.
+func (c Current) tempHoursAgo(hours int) (float64, bool) {
+	i := len(c.PastTemps) - 1 - hours
+	if hours < 1 || i < 0 || c.PastTemps[i] == nil {
+		return 0, false
+	}
+	return *c.PastTemps[i], true
+}
+
+// isNight는 t가 일몰 이후이거나 일출 이전인지 본다.
+// 일출/일몰을 모르면 낮으로 본다.
+func (c Current) isNight(t time.Time) bool {
+	if c.Sunrise.IsZero() || c.Sunset.IsZero() {
+		return false
+	}
+	return t.Before(c.Sunrise) || !t.Before(c.Sunset)
+}
+
+// ErrNoCurrentData는 forecast 응답에 current가 없거나 null일 때다.
+// 0°C 같은 그럴듯한 값을 출력하지 않기 위해 구분한다.
+var ErrNoCurrentData = errors.New("forecast response has no current data")
+
 // ---------- Open-Meteo: Air Quality ----------
 type AirQualityResponse struct {
 	Current AirQualityCurrent `json:"current"`
+	Hourly  struct {
+		PM25 []*float64 `json:"pm2_5"` // --pm25-24h: 최근 23시간 + 현재 시각
+	} `json:"hourly"`
 }
 
 type AirQualityCurrent struct {
-	PM10  float64 `json:"pm10"`  // 미세먼지
-	PM25  float64 `json:"pm2_5"` // 초미세먼지
-	AQIUS int     `json:"us_aqi"`
+	PM10  float64 `json:"pm10"`         // 미세먼지
+	PM25  float64 `json:"pm2_5"`        // 초미세먼지
+	AQIUS *int    `json:"us_aqi"`       // 지역에 따라 누락될 수 있다.
+	AQIEU *int    `json:"european_aqi"` // --aqi-standard=eu 일 때만 요청
+
+	// PM25Avg24h는 최근 24시간 PM2.5 평균이다. PM25AvgHours는 평균에 쓴 시간 수 (결측 제외)
+	PM25Avg24h   *float64 `json:"pm25_avg_24h,omitempty"`
+	PM25AvgHours int      `json:"pm25_avg_hours,omitempty"`
+
+	// Clamped는 0으로 자른 음수 원값이다. (키는 JSON 필드 이름, clampNegativeAir)
+	Clamped map[string]float64 `json:"clamped,omitempty"`
+
+	// 가스 ㎍/m³ (air --detail 에서만 요청)
+	Ozone *float64 `json:"ozone"`
+	NO2   *float64 `json:"nitrogen_dioxide"`
+	SO2   *float64 `json:"sulphur_dioxide"`
+	CO    *float64 `json:"carbon_monoxide"`
+}
+
+// usAQI는 us_aqi 값을 돌려주고, 누락됐다면 PM2.5로 추정한 값과 estimated=true를 돌려준다.
+func (a AirQualityCurrent) usAQI() (aqi int, estimated bool) {
+	if a.AQIUS != nil {
+		return *a.AQIUS, false
+	}
+	return aqiFromPM25(a.PM25), true
+}
+
+const kstOffset = 9 * 60 * 60
+
+var kst = time.FixedZone("KST", kstOffset)
+
+// nowFunc는 현재 시각이다. 출력 시각을 고정해야 할 때 바꿔 끼운다.
+var nowFunc = time.Now
+
+// Open-Meteo는 timezone을 주면 시각을 오프셋 없는 현지 시각으로 준다.
+const openMeteoTimeLayout = "2006-01-02T15:04"
+
+// ---------- Options ----------
+type Options struct {
+	// Timeout은 각 엔드포인트 타임아웃의 기본값이다.
+	Timeout time.Duration
+
+	// 엔드포인트별 타임아웃 (0이면 Timeout 사용)
+	// 지오코딩은 직렬(blocking) 구간이고, 날씨/공기질은 병렬로 호출된다.
+	GeocodeTimeout  time.Duration
+	ForecastTimeout time.Duration
+	AirTimeout      time.Duration
+
+	// Deadline이 있으면 조회 한 번 전체가 이 시간 안에 끝나야 한다. (--deadline)
+	Deadline time.Duration
+
+	// StrictHTTPS면 TLS 1.2 이상만 허용하고, Pins가 있으면 Open-Meteo 인증서 지문을 확인한다.
+	StrictHTTPS bool
+	Pins        []string
+
+	// ConfigFile은 --profile-file 값이다. (비어 있으면 $WEATHER_CONFIG, 기본 위치)
+	ConfigFile string
+
+	// CacheDir은 --cache-dir 값이다. (비어 있으면 $WEATHER_CACHE_DIR, 기본 위치)
+	// NoCache면 캐시를 읽지도 쓰지도 않는다.
+	// RefreshGeocode면 지오코딩 캐시만 읽지 않고, 새 결과는 다시 써 둔다.
+	CacheDir       string
+	NoCache        bool
+	RefreshGeocode bool
+
+	// GeocodeProvider는 "openmeteo"(기본) 또는 "local"이다. local은 GeocodeDB CSV를 쓴다.
+	GeocodeProvider string
+	GeocodeDB       string
+
+	// Geocoder가 있으면 GeocodeProvider 대신 이것을 쓴다. (플래그 없음, 테스트나 다른 Geocoder를 넣을 때)
+	Geocoder Geocoder
+
+	// 엔드포인트별 base URL 목록 (쉼표 구분, 비어 있으면 기본 호스트)
+	GeocodeURLs  string
+	ForecastURLs string
+	AirURLs      string
+	ArchiveURLs  string
+
+	// PrivacyRound면 API에 보내는 좌표를 PrivacyGrid(°) 간격으로 반올림한다.
+	PrivacyRound bool
+	PrivacyGrid  float64
+
+	// GeocodeCount는 지오코딩에서 받아 볼 후보 수다. (1 ~ maxGeocodeCount)
+	GeocodeCount int
+
+	// GridCache가 0보다 크면 좌표를 그 간격(도)으로 반올림해 날씨/대기질 응답을 캐시한다.
+	GridCache float64
+
+	// RequestID는 이번 실행의 모든 요청에 붙는 X-Request-ID다.
+	RequestID string
+
+	// Verbose면 요청 URL 등 디버그 정보를 stderr에 출력한다.
+	Verbose bool
+
+	// 실패 시 기본은 짧은 안내 문구다. DebugErrors (또는 $WEATHER_DEBUG) 면 감싼 에러 전체를 보여준다.
+	// ConciseErrors는 $WEATHER_DEBUG 가 있어도 짧은 문구를 쓴다.
+	DebugErrors   bool
+	ConciseErrors bool
+
+	// Here면 도시 대신 IP 기반 위치를 사용한다.
+	Here bool
+
+	// CoordsArg는 --coords "lat,lon" 값이다. Coords는 검사를 마친 위치 (있으면 지오코딩을 건너뛴다)
+	CoordsArg string
+	Coords    *GeoResult
+
+	// Label이 있으면 출력에 쓰는 위치 이름만 바꾼다. (좌표는 그대로, 예: "우리 집")
+	Label string
+
+	// AlsoTZ는 --also-tz 쉼표 목록이다. Zones는 검사를 마친 시간대 (헤더에 각 시각을 덧붙인다)
+	AlsoTZ string
+	Zones  []*time.Location
+
+	// BestEffort면 병렬 호출 중 하나가 실패해도 나머지를 기다려 부분 결과를 출력한다.
+	BestEffort bool
+
+	// FailFastGeocode면 --from 배치에서 첫 실패 시 나머지 도시를 건너뛴다.
+	FailFastGeocode bool
+
+	// Sort가 "condition"이면 --from 결과를 모두 받은 뒤 날씨 심각도 순으로 출력한다.
+	Sort string
+
+	// AirDetail이면 (air 명령) 오염물질별 상세를 출력한다.
+	AirDetail bool
+
+	// DryRun이면 지오코딩까지만 수행하고 호출할 URL을 출력한다.
+	DryRun bool
+
+	// Moon이면 달 위상 줄을 추가로 출력한다.
+	Moon bool
+
+	// Precision은 필드별 소수점 자릿수다. (nil이면 기본값)
+	Precision Precision
+
+	// NoHeader면 첫 줄(도시 + 시각)을 생략한다.
+	NoHeader bool
+
+	// Unit은 온도 단위 ("c", "f", 위치 확인 후 정하는 "auto"), Lang은 지역명 언어 ("ko" 또는 "en")다.
+	Unit string
+	Lang string
+
+	// DualLang이면 (--lang=ko,en) 요약 라벨을 "한국어 (English)"로 함께 쓴다. Lang은 첫 언어다.
+	DualLang bool
+
+	// WindScale이 "beaufort"면 풍속 대신 Beaufort 단계로 표시한다.
+	WindScale string
+
+	// VsNormal이면 최근 몇 년 같은 날 같은 시각 평균과 비교한다.
+	VsNormal bool
+
+	// Advice면 옷차림/우산 등 짧은 조언을 덧붙인다.
+	Advice bool
+
+	// AQIStandard는 대기질 지수 기준이다. (us, eu)
+	AQIStandard string
+
+	// Color는 --color 값 (auto, always, never), UseColor는 validateOptions에서 정한 결과다.
+	Color    string
+	UseColor bool
+
+	// IconSet은 "emoji" 또는 "ascii"다. ("auto"는 validateOptions에서 결정)
+	IconSet string
+
+	// NoEmojiWidthHack이면 이모지 아이콘 뒤 여백을 한 칸만 둔다.
+	NoEmojiWidthHack bool
+
+	// ThresholdFile은 등급 기준을 덮어쓰는 JSON 파일 경로다.
+	ThresholdFile string
+
+	// Explain이면 등급 뒤에 해당 기준 구간과 현재 값을 덧붙인다.
+	Explain bool
+
+	// CompactAir면 대기질 섹션을 한 줄로 출력한다.
+	CompactAir bool
+
+	// JSON이면 SummaryJSON 형태로 출력한다.
+	// JSONLines면 들여쓰기 없이 결과마다 한 줄씩 출력한다. (JSONL, --json 포함)
+	// JSONCompact는 --json 출력의 들여쓰기만 없앤다. (로그용)
+	JSON        bool
+	JSONLines   bool
+	JSONCompact bool
+
+	// Webhook이 있으면 조회 후 SummaryJSON을 POST한다.
+	// ExitOnWarning이면 이런 부가 작업의 실패도 에러로 종료한다.
+	Webhook       string
+	ExitOnWarning bool
+
+	// Validate면 믿기 어려운 값 (기온 -999, 음수 AQI 등)을 stderr에 경고한다. (Report.Validate)
+	Validate bool
+
+	// SaveJSON이 있으면 조회마다 SummaryJSON을 그 폴더에 파일로 남긴다.
+	SaveJSON string
+
+	// HistoryDB가 있으면 조회마다 그 SQLite 파일의 reports 테이블에 한 줄씩 쌓는다. (modernc.org/sqlite)
+	HistoryDB string
+
+	// MinTempAlert, MaxTempAlert는 기온 경고 기준이다. (--unit 단위, 주지 않으면 꺼짐)
+	MinTempAlert optFloat
+	MaxTempAlert optFloat
+
+	// IfCond는 --if 조건이다. 맞으면 기온 경고와 같이 exit 3 으로 끝낸다. (--unit 단위)
+	IfCond tempCond
+
+	// DetailLevel은 --detail-level 프리셋이다. (0~3, 기본 1)
+	DetailLevel int
+
+	// QR이면 위치의 지도 URL을 QR 코드로 함께 출력한다. (터미널일 때만)
+	QR bool
+
+	// Markdown이면 Markdown 표로 출력한다.
+	Markdown bool
+
+	// PM25Avg면 PM2.5 최근 24시간 평균을 같이 보여준다.
+	PM25Avg bool
+
+	// SinceSunrise면 낮 시간이 얼마나 지났는지 (밤이면 일출까지 남은 시간) 보여준다.
+	SinceSunrise bool
+
+	// ClampNegativeAQI면 음수 AQI/PM 값을 등급 매기기 전에 0으로 자른다. (기본 켜짐)
+	ClampNegativeAQI bool
+
+	// GroupBy는 compare 출력 방식이다. "grade"면 표 대신 AQI 등급별로 묶는다.
+	GroupBy string
+
+	// TopPollutant면 등급이 가장 나쁜 오염물질 (같으면 모두)을 한 줄로 알려준다.
+	TopPollutant bool
+
+	// AQIWarnAt > 0 이면 --advice 의 마스크 조언을 US AQI가 이 값 이상일 때만 낸다.
+	AQIWarnAt int
+
+	// PrecipWindow > 0 이면 앞으로 그 시간 안에 강수 확률이 PrecipThreshold(%) 이상인 첫 시각을 알려준다.
+	PrecipWindow    int
+	PrecipThreshold float64
+
+	// SummaryOrder는 --summary-order 쉼표 목록이다. Sections는 검사를 마친 섹션 이름 (나머지는 기본 순서로 뒤에)
+	SummaryOrder string
+	Sections     []string
+
+	// FeelsFirst면 기온 줄을 체감 온도와 쾌적도로 시작하고 실제 기온은 괄호에 둔다.
+	FeelsFirst bool
+
+	// HeatIndex면 덥고 습할 때 (27°C, 습도 40% 이상) NWS 열지수 줄을 더한다.
+	HeatIndex bool
+
+	// WindChill이면 춥고 바람 불 때 (10°C 이하, 4.8km/h 초과) 풍속냉각 체감 온도 줄을 더한다.
+	WindChill bool
+
+	// RoundDownPrecip면 강수 확률을 반올림 대신 내림하고 원값과 구간을 같이 보여준다.
+	RoundDownPrecip bool
+
+	// PrecipStep > 0 이면 화면의 강수 확률을 그 간격(%)으로 반올림한다. (63 → 65, JSON은 원값)
+	PrecipStep float64
+
+	// PastHours가 있으면 기온 줄에 그 시간 전 기온을 같이 보여준다.
+	PastHours int
+
+	// Format이 있으면 text/template로 출력한다. FieldMissing은 없는 필드 처리 (error, empty)
+	// Template은 main에서 한 번 파싱한 결과이고, FormatSource는 에러에 쓰는 출처다. (--format, --format-file <path>)
+	Format       string
+	FieldMissing string
+	Template     *template.Template
+	FormatSource string
+
+	// A11y면 이모지/기호 없이 스크린 리더용 문장으로 출력한다.
+	A11y bool
 }
 
-func RunNow(city string) error {
-	client := &http.Client{Timeout: 8 * time.Second}
+func (o Options) timeoutOr(d time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return 8 * time.Second
+}
 
-	loc, err := geocode(client, city)
+func (o Options) lang() string {
+	if o.Lang == "" {
+		return "ko"
+	}
+	return o.Lang
+}
+
+// cache는 사용할 디스크 캐시를 돌려준다. 비활성화면 아무 것도 하지 않는 캐시다.
+func (o Options) cache() diskCache {
+	if o.NoCache {
+		return diskCache{}
+	}
+	dir, err := cacheDir(o.CacheDir)
+	if err != nil {
+		return diskCache{}
+	}
+	return diskCache{dir: dir}
+}
+
+// withDeadline은 opts.Deadline이 있으면 조회 한 번 전체(지오코딩, 병렬 호출)에 건다.
+func (o Options) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.Deadline <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.Deadline)
+}
+
+// deadlineErr는 전체 deadline 때문에 실패했으면 그렇다고 알려 준다.
+func (o Options) deadlineErr(ctx context.Context, err error) error {
+	if err != nil && o.Deadline > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("deadline %s exceeded (--deadline): %w", o.Deadline, err)
+	}
+	return err
+}
+
+func (o Options) imperial() bool {
+	return o.Unit == "f"
+}
+
+func RunNow(ctx context.Context, city string, opts Options) error {
+	client, err := newHTTPClient(opts)
 	if err != nil {
 		return err
 	}
 
-	var (
-		w  Current
-		aq AirQualityCurrent
+	ctx, cancel := opts.withDeadline(ctx)
+	defer cancel()
+
+	r, opts, err := fetchReport(ctx, client, city, opts)
+	if err != nil || opts.DryRun {
+		return opts.deadlineErr(ctx, err)
+	}
+	return emitReport(ctx, client, r, opts)
+}
+
+// fetchReport는 도시를 찾고 날씨/대기질을 가져와 Report로 묶는다.
+// --unit=auto 는 위치를 알아야 정해지므로 확정된 opts도 함께 돌려준다.
+// opts.DryRun이면 URL만 출력하고 빈 Report를 돌려준다.
+func fetchReport(ctx context.Context, client *http.Client, city string, opts Options) (Report, Options, error) {
+	ctx, meta := withResponseMeta(ctx) // 이 도시의 응답만 Report.Meta 에 남긴다.
+	loc, err := resolveCity(ctx, client, city, opts)
+	if err != nil {
+		return Report{}, opts, err
+	}
+	if opts.Unit == unitAuto {
+		opts.Unit = defaultUnitForCountry(loc.CountryCode)
+	}
+
+	if opts.DryRun {
+		printDryRun(loc, opts)
+		return Report{}, opts, nil
+	}
 
-		wErr  error
-		aqErr error
-	)
+	res, err := fetchAll(ctx, client, loc, opts)
+	if err != nil {
+		return Report{}, opts, err
+	}
+	for _, e := range []error{res.WeatherErr, res.AirErr, res.NormalErr} {
+		if e != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", e)
+		}
+	}
 
-	var wg sync.WaitGroup
-	wg.Add(2)
+	return Report{
+		Location:   loc,
+		Time:       nowFunc().In(kst),
+		Weather:    res.Weather,
+		Air:        res.Air,
+		WeatherErr: res.WeatherErr,
+		AirErr:     res.AirErr,
+		Normal:     res.Normal,
+		Meta:       meta.snapshot(),
+	}, opts, nil
+}
 
-	// 날씨 병렬 호출
-	go func() {
-		defer wg.Done()
-		w, wErr = fetchCurrentWeather(client, loc.Latitude, loc.Longitude)
-	}()
+// emitReport는 Report를 출력하고, --save-json, --webhook 이 있으면 저장/POST한다.
+func emitReport(ctx context.Context, client *http.Client, r Report, opts Options) error {
+	if err := newOutputter(opts).Output(r); err != nil {
+		return err
+	}
+	alerted := r.WeatherErr == nil && len(tempAlerts(r.Weather, opts)) > 0
 
-	// 공기질 병렬 호출
-	go func() {
-		defer wg.Done()
-		aq, aqErr = fetchAirQuality(client, loc.Latitude, loc.Longitude)
-	}()
+	if opts.Validate {
+		if err := reportValidation(r, opts); err != nil {
+			return err
+		}
+	}
 
-	wg.Wait()
+	if opts.QR && !opts.JSON {
+		if isTerminal(os.Stdout) {
+			if err := printQR(r.Location, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
+		} else if opts.Verbose {
+			fmt.Fprintln(os.Stderr, "debug: --qr skipped (stdout is not a terminal)")
+		}
+	}
 
-	if wErr != nil {
-		return wErr
+	if opts.SaveJSON != "" {
+		path, err := saveSnapshot(opts.SaveJSON, r, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			if opts.ExitOnWarning {
+				return err
+			}
+		} else if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: snapshot saved to %s\n", path)
+		}
 	}
-	if aqErr != nil {
-		return aqErr
+
+	if opts.HistoryDB != "" {
+		if err := appendHistory(ctx, opts.HistoryDB, r, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			if opts.ExitOnWarning {
+				return err
+			}
+		} else if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: report appended to %s\n", opts.HistoryDB)
+		}
 	}
 
-	printSummary(loc, w, aq)
+	if opts.Webhook != "" {
+		if err := postWebhook(ctx, client, opts.Webhook, newSummaryJSON(r, opts)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			if opts.ExitOnWarning {
+				return err
+			}
+		} else if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "debug: webhook delivered to %s\n", opts.Webhook)
+		}
+	}
+	if alerted {
+		return errTempAlert
+	}
 	return nil
 }
 
-// ---------- Output ----------
-func printSummary(loc GeoResult, w Current, aq AirQualityCurrent) {
-	now := time.Now().In(time.FixedZone("KST", 9*60*60))
+// fetchResults는 병렬 호출 결과를 모은다.
+// 각 goroutine은 자기 필드만 쓰고, Wait() 이후에만 읽는다.
+type fetchResults struct {
+	Weather Current
+	Air     AirQualityCurrent
+
+	// best-effort 모드에서만 채워진다.
+	WeatherErr error
+	AirErr     error
+
+	// --vs-normal (실패해도 경고만)
+	Normal    *normalTemp
+	NormalErr error
+}
+
+// fetchAll은 날씨와 공기질을 병렬로 가져온다.
+// 기본은 한쪽이 실패하면 공유 context를 취소해 다른 쪽도 바로 멈춘다.
+// opts.BestEffort면 끝까지 기다리고, 둘 다 실패했을 때만 에러를 돌려준다.
+func fetchAll(ctx context.Context, client *http.Client, loc GeoResult, opts Options) (fetchResults, error) {
+	var res fetchResults
+
+	weather := func(ctx context.Context) error {
+		wctx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.ForecastTimeout))
+		defer cancel()
+		q := forecastQuery(opts)
+		w, err := cachedByGrid(opts, "forecast", loc.Latitude, loc.Longitude, q, func() (Current, error) {
+			return fetchCurrentWeather(wctx, client, loc.Latitude, loc.Longitude, q)
+		})
+		if err != nil {
+			return err
+		}
+		res.Weather = w
+		return nil
+	}
+
+	air := func(ctx context.Context) error {
+		actx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.AirTimeout))
+		defer cancel()
+		q := airQuery(opts, opts.DetailLevel >= detailAll)
+		aq, err := cachedByGrid(opts, "air", loc.Latitude, loc.Longitude, q, func() (AirQualityCurrent, error) {
+			return fetchAirQuality(actx, client, loc.Latitude, loc.Longitude, q)
+		})
+		if err != nil {
+			return err
+		}
+		res.Air = clampNegativeAir(aq, opts)
+		return nil
+	}
+
+	// 부가 정보는 실패해도 전체를 멈추지 않는다. (경고만 남긴다)
+	var extras []func(ctx context.Context)
+	if opts.VsNormal {
+		extras = append(extras, func(ctx context.Context) {
+			res.Normal, res.NormalErr = fetchNormal(ctx, client, loc, nowFunc().In(kst), opts)
+		})
+	}
 
-	fmt.Printf("%s | %s (KST)\n",
-		loc.Name,
-		now.Format("01-02 15:04"),
-	)
+	if opts.BestEffort {
+		var g errgroup.Group
+		g.Go(catchPanic(func() error { res.WeatherErr = weather(ctx); return nil }))
+		g.Go(catchPanic(func() error { res.AirErr = air(ctx); return nil }))
+		for _, fn := range extras {
+			g.Go(catchPanic(func() error { fn(ctx); return nil }))
+		}
+		rethrowPanic(g.Wait())
 
-	fmt.Printf("%s  %.1f°C (체감 %.1f°C)  |  강수 %d%%\n",
-		iconForCode(w.WeatherCode),
-		w.Temperature2m,
-		w.ApparentTemperature,
-		w.PrecipProbability,
-	)
+		if res.WeatherErr != nil && res.AirErr != nil {
+			return fetchResults{}, res.WeatherErr
+		}
+		return res, nil
+	}
 
-	fmt.Printf("대기질 %s (AQI %d)\n",
-		aqiStatus(aq.AQIUS),
-		aq.AQIUS,
-	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(catchPanic(func() error { return weather(gctx) }))
+	g.Go(catchPanic(func() error { return air(gctx) }))
+	for _, fn := range extras {
+		g.Go(catchPanic(func() error { fn(gctx); return nil }))
+	}
 
-	fmt.Printf("미세먼지(PM10) %s | 초미세먼지(PM2.5) %s\n",
-		pm10GradeKR(aq.PM10),
-		pm25GradeKR(aq.PM25),
-	)
+	if err := g.Wait(); err != nil {
+		rethrowPanic(err)
+		return fetchResults{}, err
+	}
+	return res, nil
+}
+
+// resolveCity는 지오코딩 타임아웃을 적용해 opts의 Geocoder를 호출한다.
+// opts.Coords (--coords) 가 있으면 그 좌표를, opts.Here면 city 대신 IP 기반 위치를 쓴다.
+// --round-coordinates-privacy 면 좌표를 반올림한다.
+// --label 이 있으면 이름만 바꾼다.
+func resolveCity(ctx context.Context, client *http.Client, city string, opts Options) (GeoResult, error) {
+	loc, err := lookupCity(ctx, client, city, opts)
+	if err != nil {
+		return GeoResult{}, err
+	}
+	if opts.PrivacyRound {
+		loc = roundLocation(loc, opts.PrivacyGrid)
+	}
+	if opts.Label != "" {
+		loc.Name = opts.Label
+	}
+	return loc, nil
+}
+
+func lookupCity(ctx context.Context, client *http.Client, city string, opts Options) (GeoResult, error) {
+	if opts.Coords != nil {
+		return *opts.Coords, nil
+	}
+	gctx, cancel := context.WithTimeout(ctx, opts.timeoutOr(opts.GeocodeTimeout))
+	defer cancel()
+	if opts.Here {
+		return geolocateByIP(gctx, client)
+	}
+
+	if code := strings.TrimSpace(city); looksLikeAirportCode(code) {
+		if loc, ok := lookupAirport(code, opts.lang()); ok {
+			return loc, nil
+		}
+	}
+
+	g, err := newGeocoder(client, opts)
+	if err != nil {
+		return GeoResult{}, err
+	}
+	return g.Geocode(gctx, city)
 }
 
 // ---------- API ----------
-func geocode(client *http.Client, city string) (GeoResult, error) {
-	q := url.QueryEscape(city)
-	u := fmt.Sprintf("https://geocoding-api.open-meteo.com/v1/search?name=%s&count=1&language=ko&format=json", q)
+// 기본은 API 첫 결과 하나만 받는다. (defaultGeocodeCount)
+// --geocode-count 를 늘리면 그만큼 받아 인구순으로 고르고, 같은 이름의 다른 지역이 있으면 경고한다. (최대 maxGeocodeCount)
+// 영어 이름을 다시 찾을 때처럼 같은 장소를 후보 중에서 골라야 하면 geocodeCandidates개를 받는다.
+const (
+	defaultGeocodeCount = 1
+	geocodeCandidates   = 5
+	maxGeocodeCount     = 100
+)
 
-	resp, err := client.Get(u)
+func geocode(ctx context.Context, client *http.Client, city, lang string, count int) (GeoResult, error) {
+	results, err := searchPlaces(ctx, client, city, lang, count)
 	if err != nil {
-		return GeoResult{}, fmt.Errorf("geocoding request failed: %w", err)
+		return GeoResult{}, err
+	}
+	rankByPopulation(results)
+
+	for _, r := range results {
+		// 이름이 비어 있어도 좌표가 멀쩡하면 영어 이름을 찾아본 뒤에 판단한다.
+		if lang != "en" && validCoords(r) && needsEnglishName(r, city) {
+			r = withEnglishName(ctx, client, r, city)
+		}
+		if !validGeoResult(r) {
+			continue
+		}
+		if nameTie(results, r) {
+			fmt.Fprintf(os.Stderr, "warning: %s 선택됨 (같은 이름이 여러 곳, weather search %s 로 확인)\n", placeLabel(r), shellQuote(city))
+		}
+		return r, nil
+	}
+
+	return GeoResult{}, fmt.Errorf("no valid results for city: %q", city)
+}
+
+// nameTie는 chosen과 이름이 같은데 지역(admin1)이나 나라가 다른 후보가 있는지 본다. ("Portland" 오리건/메인)
+func nameTie(results []GeoResult, chosen GeoResult) bool {
+	for _, r := range results {
+		if r.ID == chosen.ID || !validGeoResult(r) || !strings.EqualFold(r.Name, chosen.Name) {
+			continue
+		}
+		if r.Admin1 != chosen.Admin1 || r.Country != chosen.Country {
+			return true
+		}
+	}
+	return false
+}
+
+// rankByPopulation은 인구 많은 순으로 정렬한다. 같으면 API 순서를 유지한다.
+func rankByPopulation(results []GeoResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Population > results[j].Population
+	})
+}
+
+// needsEnglishName은 현지어 결과 이름이 비었거나, 한글이 아닌 검색어를
+// 그대로 돌려준 경우(번역된 이름이 없는 작은 도시)인지 본다.
+func needsEnglishName(r GeoResult, city string) bool {
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		return true
+	}
+	q := strings.TrimSpace(city)
+	return strings.EqualFold(name, q) && !containsHangul(q)
+}
+
+func containsHangul(s string) bool {
+	for _, r := range s {
+		if unicode.Is(unicode.Hangul, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// withEnglishName은 language=en 으로 다시 조회해 같은 장소의 영어 이름을 쓴다.
+// 실패하거나 같은 장소를 못 찾으면 원래 결과를 그대로 돌려준다. (best-effort)
+func withEnglishName(ctx context.Context, client *http.Client, r GeoResult, city string) GeoResult {
+	results, err := searchPlaces(ctx, client, city, "en", geocodeCandidates)
+	if err != nil {
+		return r
+	}
+	var en GeoResult
+	for _, c := range results {
+		if c.ID == r.ID {
+			en = c
+			break
+		}
+	}
+	if strings.TrimSpace(en.Name) == "" {
+		return r
+	}
+	r.Name = en.Name
+	r.Query = city
+	if r.Country == "" {
+		r.Country = en.Country
+	}
+	return r
+}
+
+// searchPlaces는 지오코딩 결과를 최대 count개까지 그대로 돌려준다.
+func searchPlaces(ctx context.Context, client *http.Client, city, lang string, count int) ([]GeoResult, error) {
+	city, err := normalizeCity(city)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := mirrorURLs(endpoints.Geocode, func(base string) string {
+		return buildGeocodeURL(base, city, lang, count)
+	})
+	resp, err := fetchWithFallback(ctx, client, urls)
+	if err != nil {
+		return nil, requestError("geocoding", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return GeoResult{}, fmt.Errorf("geocoding bad status: %s", resp.Status)
+		return nil, statusError("geocoding", resp)
 	}
 
 	var gr GeoResponse
 	if err := json.NewDecoder(resp.Body).Decode(&gr); err != nil {
-		return GeoResult{}, fmt.Errorf("geocoding decode failed: %w", err)
+		return nil, decodeError("geocoding", err)
 	}
 
 	if len(gr.Results) == 0 {
-		return GeoResult{}, fmt.Errorf("no results for city: %q", city)
+		return nil, fmt.Errorf("no results for city: %q", city)
+	}
+
+	return gr.Results, nil
+}
+
+const maxCityLen = 100
+
+// normalizeCity는 제어 문자 등 출력 불가능한 문자를 제거하고 공백을 정리한다.
+// 셸에서 잘못 붙여 넣은 긴 문자열은 지오코딩 전에 거절한다.
+func normalizeCity(city string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		if !unicode.IsPrint(r) {
+			return -1
+		}
+		return r
+	}, city)
+	cleaned = strings.Join(strings.Fields(cleaned), " ")
+
+	if cleaned == "" {
+		return "", fmt.Errorf("city name is empty")
 	}
+	if n := utf8.RuneCountInString(cleaned); n > maxCityLen {
+		return "", fmt.Errorf("city name too long: %d chars (max %d)", n, maxCityLen)
+	}
+	return cleaned, nil
+}
 
-	return gr.Results[0], nil
+// validGeoResult는 좌표가 누락된 (0,0) 결과나 이름 없는 결과를 걸러낸다.
+func validGeoResult(r GeoResult) bool {
+	return strings.TrimSpace(r.Name) != "" && validCoords(r)
 }
 
-func fetchCurrentWeather(client *http.Client, lat, lon float64) (Current, error) {
-	u := fmt.Sprintf(
-		"https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&timezone=Asia%%2FSeoul&current=temperature_2m,apparent_temperature,precipitation_probability,weather_code",
-		lat, lon,
-	)
+// validCoords는 좌표가 (0,0)이 아니고 범위 안인지만 본다.
+func validCoords(r GeoResult) bool {
+	if r.Latitude == 0 && r.Longitude == 0 {
+		return false
+	}
+	return r.Latitude >= -90 && r.Latitude <= 90 &&
+		r.Longitude >= -180 && r.Longitude <= 180
+}
 
-	resp, err := client.Get(u)
+func fetchCurrentWeather(ctx context.Context, client *http.Client, lat, lon float64, q queryOpts) (Current, error) {
+	urls := mirrorURLs(endpoints.Forecast, func(base string) string {
+		return buildForecastURL(base, lat, lon, q)
+	})
+	resp, err := fetchWithFallback(ctx, client, urls)
 	if err != nil {
-		return Current{}, fmt.Errorf("weather request failed: %w", err)
+		return Current{}, requestError("weather", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return Current{}, fmt.Errorf("weather bad status: %s", resp.Status)
+		return Current{}, statusError("weather", resp)
 	}
 
 	var data OpenMeteoResponse
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return Current{}, fmt.Errorf("weather decode failed: %w", err)
+		return Current{}, decodeError("weather", err)
+	}
+
+	cur, err := currentFromResponse(data, q)
+	if err != nil {
+		return Current{}, decodeError("weather", err) // errors.Is(err, ErrNoCurrentData) 로 구분된다.
 	}
+	return cur, nil
+}
 
-	return data.Current, nil
+// currentFromResponse는 forecast 응답의 current에 daily/hourly 값을 채운다.
+// daily/hourly 시각은 응답 시간대 (위치의 현지 시각)로 읽는다. 일출/일몰이 위치의 하루와 맞아야 하기 때문이다.
+func currentFromResponse(data OpenMeteoResponse, q queryOpts) (Current, error) {
+	if data.Current == nil {
+		return Current{}, ErrNoCurrentData
+	}
+	loc := data.location()
+	cur := *data.Current
+	if len(data.Daily.Sunrise) > 0 && len(data.Daily.Sunset) > 0 {
+		cur.Sunrise, _ = time.ParseInLocation(openMeteoTimeLayout, data.Daily.Sunrise[0], loc)
+		cur.Sunset, _ = time.ParseInLocation(openMeteoTimeLayout, data.Daily.Sunset[0], loc)
+	}
+	if len(data.Daily.Sunrise) > 1 {
+		cur.NextSunrise, _ = time.ParseInLocation(openMeteoTimeLayout, data.Daily.Sunrise[1], loc)
+	}
+	if len(data.Daily.TempMax) > 0 {
+		cur.TodayMax = data.Daily.TempMax[0]
+	}
+	if len(data.Daily.TempMin) > 0 {
+		cur.TodayMin = data.Daily.TempMin[0]
+	}
+	// past_hours개 다음이 현재 시각이고, 그 뒤는 --precip-window 용 앞으로의 시간이다.
+	now := q.PastHours
+	cur.PastTemps = data.Hourly.Temperature
+	if len(cur.PastTemps) > now+1 {
+		cur.PastTemps = cur.PastTemps[:now+1]
+	}
+	for i := now + 1; i < len(data.Hourly.Time) && i < len(data.Hourly.PrecipProb); i++ {
+		t, err := time.ParseInLocation(openMeteoTimeLayout, data.Hourly.Time[i], loc)
+		if err != nil || data.Hourly.PrecipProb[i] == nil {
+			continue
+		}
+		cur.NextPrecip = append(cur.NextPrecip, precipHour{Time: t, Prob: *data.Hourly.PrecipProb[i]})
+	}
+	return cur, nil
 }
 
-func fetchAirQuality(client *http.Client, lat, lon float64) (AirQualityCurrent, error) {
-	u := fmt.Sprintf(
-		"https://air-quality-api.open-meteo.com/v1/air-quality?latitude=%f&longitude=%f&timezone=Asia%%2FSeoul&current=pm10,pm2_5,us_aqi",
-		lat, lon,
-	)
+// location은 응답 시간대다. tzdata가 없으면 utc_offset_seconds로, 시간대가 없으면 (예전 캐시 등) KST로 본다.
+func (r OpenMeteoResponse) location() *time.Location {
+	if r.Timezone == "" {
+		return kst
+	}
+	if loc, err := time.LoadLocation(r.Timezone); err == nil {
+		return loc
+	}
+	return time.FixedZone(r.TimezoneAbbr, r.UTCOffsetSeconds)
+}
 
-	resp, err := client.Get(u)
+func fetchAirQuality(ctx context.Context, client *http.Client, lat, lon float64, q queryOpts) (AirQualityCurrent, error) {
+	urls := mirrorURLs(endpoints.Air, func(base string) string {
+		return buildAirURL(base, lat, lon, q)
+	})
+	resp, err := fetchWithFallback(ctx, client, urls)
 	if err != nil {
-		return AirQualityCurrent{}, fmt.Errorf("air quality request failed: %w", err)
+		return AirQualityCurrent{}, requestError("air quality", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return AirQualityCurrent{}, fmt.Errorf("air quality bad status: %s", resp.Status)
+		return AirQualityCurrent{}, statusError("air quality", resp)
 	}
 
 	var data AirQualityResponse
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return AirQualityCurrent{}, fmt.Errorf("air quality decode failed: %w", err)
+		return AirQualityCurrent{}, decodeError("air quality", err)
+	}
+
+	aq := data.Current
+	if avg, n := meanPresent(data.Hourly.PM25); n > 0 {
+		aq.PM25Avg24h, aq.PM25AvgHours = &avg, n
 	}
+	return aq, nil
+}
+
+// ---------- URL builders ----------
+const (
+	geocodeBaseURL  = "https://geocoding-api.open-meteo.com/v1/search"
+	forecastBaseURL = "https://api.open-meteo.com/v1/forecast"
+	airBaseURL      = "https://air-quality-api.open-meteo.com/v1/air-quality"
+	archiveBaseURL  = "https://archive-api.open-meteo.com/v1/archive"
+)
+
+// queryOpts는 forecast/air-quality/archive 요청에 공통으로 붙는 파라미터다.
+type queryOpts struct {
+	Timezone        string
+	Current         []string
+	TemperatureUnit string // "" 이면 API 기본값 (celsius)
+	WindSpeedUnit   string // "" 이면 API 기본값 (kmh)
+
+	Hourly []string
+	Daily  []string
+	Days   int // forecast_days
 
-	return data.Current, nil
+	PastHours     int    // past_hours (hourly)
+	ForecastHours int    // forecast_hours (hourly)
+	StartDate     string // YYYY-MM-DD
+	EndDate       string
+}
+
+func forecastQuery(opts Options) queryOpts {
+	q := queryOpts{
+		Timezone: "auto", // 일출/일몰은 위치의 하루 기준이어야 한다. (서쪽 도시는 KST 하루로 받으면 일몰이 일출보다 먼저다)
+		Current:  []string{"temperature_2m", "apparent_temperature", "precipitation_probability", "precipitation", "weather_code", "visibility", "uv_index", "wind_speed_10m", "relative_humidity_2m"},
+		Daily:    []string{"sunrise", "sunset", "temperature_2m_max", "temperature_2m_min"},
+		Days:     1,
+
+		// 기온 추세(trendHours)와 --past-hours 용: 최근 몇 시간 + 현재 시각
+		Hourly:        []string{"temperature_2m"},
+		PastHours:     max(trendHours, opts.PastHours),
+		ForecastHours: 1,
+	}
+	if opts.PrecipWindow > 0 {
+		q.Hourly = append(q.Hourly, "precipitation_probability")
+		q.ForecastHours = 1 + opts.PrecipWindow
+	}
+	if opts.SinceSunrise {
+		q.Days = 2 // 일몰 후에 내일 일출까지 남은 시간을 보여주기 위해
+	}
+	if opts.imperial() {
+		q.TemperatureUnit = "fahrenheit"
+		q.WindSpeedUnit = "mph"
+	}
+	return q
+}
+
+func airQuery(opts Options, gases bool) queryOpts {
+	current := []string{"pm10", "pm2_5", "us_aqi"}
+	if opts.AQIStandard == aqiStandardEU {
+		current = append(current, "european_aqi")
+	}
+	if gases {
+		current = append(current, "ozone", "nitrogen_dioxide", "sulphur_dioxide", "carbon_monoxide")
+	}
+	q := queryOpts{
+		Timezone: "Asia/Seoul",
+		Current:  current,
+	}
+	if opts.PM25Avg {
+		q.Hourly = []string{"pm2_5"}
+		q.PastHours, q.ForecastHours = pm25AvgHours-1, 1
+	}
+	return q
+}
+
+// url.Values.Encode는 키를 정렬하므로 결과 문자열이 항상 같다.
+func buildGeocodeURL(base, city, lang string, count int) string {
+	v := url.Values{}
+	v.Set("name", city)
+	v.Set("count", strconv.Itoa(count))
+	v.Set("language", lang)
+	v.Set("format", "json")
+	return base + "?" + v.Encode()
+}
+
+func buildForecastURL(base string, lat, lon float64, opts queryOpts) string {
+	return base + "?" + coordValues(lat, lon, opts).Encode()
+}
+
+func buildAirURL(base string, lat, lon float64, opts queryOpts) string {
+	return base + "?" + coordValues(lat, lon, opts).Encode()
+}
+
+func coordValues(lat, lon float64, opts queryOpts) url.Values {
+	v := url.Values{}
+	v.Set("latitude", strconv.FormatFloat(lat, 'f', -1, 64))
+	v.Set("longitude", strconv.FormatFloat(lon, 'f', -1, 64))
+	if opts.Timezone != "" {
+		v.Set("timezone", opts.Timezone)
+	}
+	if len(opts.Current) > 0 {
+		v.Set("current", strings.Join(opts.Current, ","))
+	}
+	if opts.TemperatureUnit != "" {
+		v.Set("temperature_unit", opts.TemperatureUnit)
+	}
+	if opts.WindSpeedUnit != "" {
+		v.Set("wind_speed_unit", opts.WindSpeedUnit)
+	}
+	if len(opts.Hourly) > 0 {
+		v.Set("hourly", strings.Join(opts.Hourly, ","))
+	}
+	if len(opts.Daily) > 0 {
+		v.Set("daily", strings.Join(opts.Daily, ","))
+	}
+	if opts.Days > 0 {
+		v.Set("forecast_days", strconv.Itoa(opts.Days))
+	}
+	if opts.PastHours > 0 {
+		v.Set("past_hours", strconv.Itoa(opts.PastHours))
+	}
+	if opts.ForecastHours > 0 {
+		v.Set("forecast_hours", strconv.Itoa(opts.ForecastHours))
+	}
+	if opts.StartDate != "" {
+		v.Set("start_date", opts.StartDate)
+	}
+	if opts.EndDate != "" {
+		v.Set("end_date", opts.EndDate)
+	}
+	return v
 }
 
 // --- helpers ---
@@ -197,67 +1114,83 @@ func fail(format string, args ...any) {
 	os.Exit(1)
 }
 
-func iconForCode(code int) string {
-	switch code {
-	case 0:
-		return "☀️  맑음"
-	case 1, 2, 3:
-		return "☁️  흐림"
-	case 45, 48:
-		return "🌫️  안개"
-	case 51, 53, 55:
-		return "🌦️  이슬비"
-	case 61, 63, 65:
-		return "🌧️  비"
-	case 71, 73, 75:
-		return "🌨️  눈"
-	case 95:
-		return "⛈️  뇌우"
-	default:
-		return "🌡️  알 수 없음"
-	}
+// usageFail은 잘못된 사용법(인자 누락 등)으로 종료한다. (exit 2)
+func usageFail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "error: "+format+"\n", args...)
+	fmt.Fprintln(os.Stderr, "run 'weather --help' for usage")
+	os.Exit(2)
 }
 
-func aqiStatus(aqi int) string {
-	switch {
-	case aqi <= 50:
-		return "좋음 😊"
-	case aqi <= 100:
-		return "보통 🙂"
-	case aqi <= 150:
-		return "나쁨 😷"
-	case aqi <= 200:
-		return "매우 나쁨 🤢"
-	default:
-		return "위험 ☠️"
+func metersToKm(m float64) float64 {
+	return m / 1000
+}
+
+func mmToInches(mm float64) float64 {
+	return mm / 25.4
+}
+
+func metersToMiles(m float64) float64 {
+	return m / 1609.344
+}
+
+func fahrenheitToCelsius(f float64) float64 {
+	return (f - 32) * 5 / 9
+}
+
+func tempSymbol(opts Options) string {
+	if opts.imperial() {
+		return "°F"
 	}
+	return "°C"
 }
 
-// ---------- Korea grading (commonly used public thresholds) ----------
-// PM10 (미세먼지) ㎍/m³
-func pm10GradeKR(pm10 float64) string {
-	switch {
-	case pm10 <= 30:
-		return "좋음"
-	case pm10 <= 80:
-		return "보통"
-	case pm10 <= 150:
-		return "나쁨"
-	default:
-		return "매우 나쁨"
+// iconForCode는 "아이콘  라벨"을 돌려준다. isNight면 맑음/흐림에 밤 아이콘을 쓴다.
+func iconForCode(code int, isNight bool) string {
+	return lookupCode(code).icon(isNight, false)
+}
+
+// ---------- US EPA AQI from PM2.5 ----------
+type aqiBreakpoint struct {
+	cLo, cHi float64
+	iLo, iHi int
+}
+
+// PM2.5 (24h, ㎍/m³) breakpoints
+var pm25Breakpoints = []aqiBreakpoint{
+	{0.0, 12.0, 0, 50},
+	{12.1, 35.4, 51, 100},
+	{35.5, 55.4, 101, 150},
+	{55.5, 150.4, 151, 200},
+	{150.5, 250.4, 201, 300},
+	{250.5, 350.4, 301, 400},
+	{350.5, 500.4, 401, 500},
+}
+
+// aqiFromPM25는 EPA 선형 보간식으로 PM2.5 농도를 AQI로 환산한다. (대략적인 추정치)
+func aqiFromPM25(pm float64) int {
+	if pm <= 0 {
+		return 0
+	}
+	c := math.Floor(pm*10) / 10 // EPA는 소수 첫째 자리에서 절사한다.
+
+	for _, bp := range pm25Breakpoints {
+		if c <= bp.cHi {
+			aqi := float64(bp.iHi-bp.iLo)/(bp.cHi-bp.cLo)*(c-bp.cLo) + float64(bp.iLo)
+			return int(math.Round(aqi))
+		}
 	}
+	return 500
 }
 
-// PM2.5 (초미세먼지) ㎍/m³
-func pm25GradeKR(pm25 float64) string {
+// ---------- Korea grading (commonly used public thresholds) ----------
+// 가시거리 km
+func visibilityGradeKR(km float64) string {
 	switch {
-	case pm25 <= 15:
+	case km >= 10:
 		return "좋음"
-	case pm25 <= 35:
+	case km >= 2:
 		return "보통"
-	case pm25 <= 75:
-		return "나쁨"
 	default:
-		return "매우 나쁨"
+		return "나쁨"
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// captureStdout는 fn이 stdout에 쓴 내용을 돌려준다.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureStderr는 fn이 stderr에 쓴 내용을 돌려준다. (경고 문구 확인용)
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, fn)
+}
+
+func captureFile(t *testing.T, f **os.File, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := *f
+	*f = w
+	defer func() { *f = orig }()
+
+	done := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- string(b)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+// fixNow는 테스트 동안 nowFunc를 t로 고정한다.
+func fixNow(t *testing.T, now time.Time) {
+	t.Helper()
+	orig := nowFunc
+	nowFunc = func() time.Time { return now }
+	t.Cleanup(func() { nowFunc = orig })
+}
+
+func ptr[T any](v T) *T { return &v }
+
+// testOptions는 validateOptions를 거친 것과 같은 기본 옵션이다. (ko, 섭씨, 이모지)
+func testOptions() Options {
+	return Options{
+		Unit:        "c",
+		Lang:        "ko",
+		IconSet:     iconSetEmoji,
+		AQIStandard: aqiStandardUS,
+		DetailLevel: detailDefault,
+		Precision:   newPrecision(),
+		NoCache:     true,
+	}
+}
+
+// testReport는 골든 출력에 쓰는 고정 Report다. (서울, 2025-03-14 15:30 KST)
+func testReport() Report {
+	now := nowFunc().In(kst)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, kst)
+	return Report{
+		Location: GeoResult{Name: "서울", Country: "대한민국", CountryCode: "KR", Latitude: 37.566, Longitude: 126.9784},
+		Time:     now,
+		Weather: Current{
+			Temperature2m:       12.3,
+			ApparentTemperature: 11,
+			PrecipProbability:   ptr(20.0),
+			WeatherCode:         0,
+			Visibility:          ptr(24000.0),
+			WindSpeed:           ptr(9.4),
+			Sunrise:             day.Add(6*time.Hour + 45*time.Minute),
+			Sunset:              day.Add(18*time.Hour + 35*time.Minute),
+		},
+		Air: AirQualityCurrent{PM10: 45, PM25: 22, AQIUS: ptr(72)},
+	}
+}
+
+func TestPrintSummaryGolden(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+
+	got := captureStdout(t, func() { printSummary(testReport(), testOptions()) })
+	const want = `서울 | 03-14 15:30 (KST)
+☀️  맑음  12.3°C (체감 11.0°C)  |  강수 20%
+가시거리 24.0km (좋음)
+바람 9.4km/h
+대기질 보통 🙂 (AQI 72)
+미세먼지(PM10) 보통 | 초미세먼지(PM2.5) 보통
+`
+	if got != want {
+		t.Errorf("printSummary output mismatch\n--- got\n%s--- want\n%s", got, want)
+	}
+}
+
+// fakeOpenMeteo는 지오코딩/날씨/대기질 API를 흉내 내는 서버를 띄우고 endpoints를 그쪽으로 돌린다.
+// places에 없는 이름은 결과 없음으로 답한다.
+func fakeOpenMeteo(t *testing.T, places map[string]GeoResult) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/search", func(w http.ResponseWriter, r *http.Request) {
+		var res GeoResponse
+		if p, ok := places[r.URL.Query().Get("name")]; ok {
+			res.Results = []GeoResult{p}
+		}
+		json.NewEncoder(w).Encode(res)
+	})
+	mux.HandleFunc("/v1/forecast", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"current":{"temperature_2m":12.3,"apparent_temperature":11,"weather_code":0,"precipitation_probability":20},
+			"daily":{"sunrise":["2025-03-14T06:45"],"sunset":["2025-03-14T18:35"]}}`)
+	})
+	mux.HandleFunc("/v1/air-quality", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"current":{"pm10":45,"pm2_5":22,"us_aqi":72}}`)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	orig := endpoints
+	endpoints = endpointURLs{
+		Geocode:  []string{srv.URL + "/v1/search"},
+		Forecast: []string{srv.URL + "/v1/forecast"},
+		Air:      []string{srv.URL + "/v1/air-quality"},
+		Archive:  []string{srv.URL + "/v1/archive"},
+	}
+	t.Cleanup(func() { endpoints = orig })
+	return srv
+}
+
+func TestSummaryNotesDualLang(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	opts := testOptions()
+	opts.DualLang = true
+
+	r := testReport()
+	r.Location.Rounded, r.Location.Query = 0.1, "seoul"
+	got := captureStdout(t, func() {
+		printHeader(r.Location, r.Time, opts)
+		fmt.Println(compactAirLine(r.Air, opts))
+		printMoon(r.Time, opts)
+	})
+	const want = `서울 (좌표 0.1° 단위로 낮춤/coordinates rounded to 0.1°) (검색어/query: seoul) | 03-14 15:30 (KST)
+대기질 (air quality) 보통 (moderate) | PM10 45.0 보통 (moderate) | PM2.5 22.0 보통 (moderate)
+달 위상 (moon phase) 🌕 보름 (full moon) (100%)
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	opts.DualLang = false
+	got = captureStdout(t, func() { printHeader(r.Location, r.Time, opts) })
+	if want := "서울 (좌표 0.1° 단위로 낮춤) (검색어: seoul) | 03-14 15:30 (KST)\n"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+}
+
+func TestPrecipTextDualLang(t *testing.T) {
+	opts := testOptions()
+	opts.DualLang = true
+	if got := precipText(nil, opts); got != "-- (확률 미제공/no probability)" {
+		t.Errorf("precipText(nil) = %q", got)
+	}
+	opts.RoundDownPrecip = true
+	if got := precipText(ptr(99.6), opts); got != "99% (매우 높음, 원값/raw 99.6%)" {
+		t.Errorf("precipText(99.6) = %q", got)
+	}
+
+	aq := AirQualityCurrent{PM10: 45, PM25: 22}
+	if got := airStatusLine(aq, opts); !strings.Contains(got, "(AQI ~72, PM2.5 기준 추정/estimated from PM2.5)") {
+		t.Errorf("airStatusLine = %q", got)
+	}
+}
+
+// current가 null이거나 없으면 0°C를 만들지 않고 ErrNoCurrentData를 담은 APIError로 실패한다.
+func TestFetchCurrentWeatherNoCurrent(t *testing.T) {
+	cases := []struct{ name, body string }{
+		{"null", `{"current":null,"daily":{"sunrise":["2025-03-14T06:45"],"sunset":["2025-03-14T18:35"]}}`},
+		{"absent", `{"latitude":37.55,"longitude":127}`},
+	}
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, c.body)
+		}))
+		orig := endpoints
+		endpoints.Forecast = []string{srv.URL}
+
+		_, err := fetchCurrentWeather(t.Context(), srv.Client(), 37.566, 126.9784, queryOpts{})
+		endpoints = orig
+		srv.Close()
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("%s: err = %v, want *APIError", c.name, err)
+		}
+		if apiErr.Endpoint != "weather" || apiErr.Reason != "decode failed" {
+			t.Errorf("%s: APIError = %+v", c.name, apiErr)
+		}
+		if !errors.Is(err, ErrNoCurrentData) {
+			t.Errorf("%s: err = %v, want ErrNoCurrentData", c.name, err)
+		}
+		if got := friendlyError(err); !strings.Contains(got, "현재 데이터가 없습니다") {
+			t.Errorf("%s: friendlyError = %q", c.name, got)
+		}
+	}
+}
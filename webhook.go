@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ---------- Webhook ----------
+// 홈 오토메이션 등에서 받을 수 있도록 조회 결과를 POST한다.
+// 실패해도 stdout 요약과 종료 코드에는 영향을 주지 않는다. (--exit-on-warning 제외)
+// webhookTimeout은 테스트에서 줄일 수 있도록 변수로 둔다.
+var webhookTimeout = 5 * time.Second
+
+func postWebhook(ctx context.Context, client *http.Client, url string, payload SummaryJSON) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook encode failed: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook bad status: %s", resp.Status)
+	}
+	return nil
+}
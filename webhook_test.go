@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPostWebhook(t *testing.T) {
+	fixNow(t, time.Date(2025, 3, 14, 15, 30, 0, 0, kst))
+	payload := newSummaryJSON(testReport(), testOptions())
+
+	var (
+		gotType   string
+		gotMethod string
+		got       SummaryJSON
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotType = r.Method, r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := postWebhook(context.Background(), srv.Client(), srv.URL, payload); err != nil {
+		t.Fatalf("postWebhook: %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %s, want POST", gotMethod)
+	}
+	if gotType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotType)
+	}
+
+	// 받은 쪽에서 다시 디코딩한 값과 같아야 한다. (시각 등은 JSON 왕복 후 비교)
+	var want SummaryJSON
+	b, _ := json.Marshal(payload)
+	_ = json.Unmarshal(b, &want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %+v, want %+v", got, want)
+	}
+}
+
+func TestPostWebhookBadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	if err := postWebhook(context.Background(), srv.Client(), srv.URL, SummaryJSON{}); err == nil {
+		t.Fatal("non-2xx response was not an error")
+	}
+}
+
+func TestPostWebhookTimeout(t *testing.T) {
+	if webhookTimeout != 5*time.Second {
+		t.Errorf("webhookTimeout = %s, want 5s", webhookTimeout)
+	}
+	orig := webhookTimeout
+	webhookTimeout = 50 * time.Millisecond
+	defer func() { webhookTimeout = orig }()
+
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	start := time.Now()
+	err := postWebhook(context.Background(), srv.Client(), srv.URL, SummaryJSON{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("postWebhook took %s, timeout not applied", elapsed)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ---------- Display width ----------
+// 터미널에서 문자열이 차지하는 칸 수를 센다. utf8 rune 개수와 다르다.
+//
+//	"맑음" = 4, "☀️" = 2, "👨‍👩‍👧" = 2, "PM2.5" = 5
+//
+// East Asian Wide/Fullwidth와 이모지는 2칸, 결합 문자·ZWJ·VS는 0칸으로 본다.
+// 터미널마다 조금씩 다르므로 정확한 값이 아니라 대부분의 터미널 기준이다.
+func displayWidth(s string) int {
+	total := 0
+	last := 0 // 직전 글자의 폭 (VS16 처리용)
+	afterZWJ := false
+
+	for _, r := range s {
+		switch {
+		case afterZWJ:
+			// ZWJ 시퀀스는 앞 글자와 합쳐져 한 글자로 그려진다.
+			afterZWJ = false
+			continue
+		case r == 0x200D: // ZWJ
+			afterZWJ = true
+			continue
+		case r == 0xFE0F: // VS16: 앞 글자를 이모지(2칸)로 그린다.
+			if last == 1 {
+				total++
+				last = 2
+			}
+			continue
+		}
+
+		w := runeWidth(r)
+		total += w
+		if w > 0 {
+			last = w
+		}
+	}
+	return total
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0:
+		return 0
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r >= 0xFE00 && r <= 0xFE0F: // variation selectors
+		return 0
+	case r >= 0x1F3FB && r <= 0x1F3FF: // 피부색 modifier
+		return 0
+	case r >= 0x1160 && r <= 0x11FF: // 한글 자모 중성·종성, 앞의 초성과 합쳐 한 글자가 된다.
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case r >= 0x1F1E6 && r <= 0x1F1FF: // regional indicator, 두 개가 국기 하나 (2칸)
+		return 1
+	}
+
+	for _, rg := range wideRanges {
+		if r < rg[0] {
+			break
+		}
+		if r <= rg[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// wideRanges는 2칸으로 그려지는 구간이다. (시작 순 정렬)
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F}, // 한글 자모 초성
+	{0x231A, 0x231B}, // ⌚⌛
+	{0x23E9, 0x23EC},
+	{0x23F0, 0x23F0},
+	{0x23F3, 0x23F3},
+	{0x25FD, 0x25FE},
+	{0x2614, 0x2615}, // ☔☕
+	{0x2648, 0x2653},
+	{0x26A1, 0x26A1}, // ⚡
+	{0x26AA, 0x26AB},
+	{0x26BD, 0x26BE},
+	{0x26C4, 0x26C5}, // ⛄⛅
+	{0x26CE, 0x26CE},
+	{0x26D4, 0x26D4},
+	{0x26EA, 0x26EA},
+	{0x26F2, 0x26F5},
+	{0x26FA, 0x26FD},
+	{0x2705, 0x2705},
+	{0x270A, 0x270B},
+	{0x2728, 0x2728},
+	{0x274C, 0x274C},
+	{0x2753, 0x2755},
+	{0x2757, 0x2757},
+	{0x2795, 0x2797},
+	{0x2E80, 0x303E},   // CJK 부수, 기호
+	{0x3041, 0x33FF},   // 가나, 호환 문자 (㎍ 포함)
+	{0x3400, 0x4DBF},   // CJK 확장 A
+	{0x4E00, 0x9FFF},   // CJK 통합 한자
+	{0xA000, 0xA4CF},   // 이 문자
+	{0xA960, 0xA97F},   // 한글 자모 확장 A
+	{0xAC00, 0xD7A3},   // 한글 음절
+	{0xF900, 0xFAFF},   // CJK 호환 한자
+	{0xFE30, 0xFE4F},   // CJK 호환 형태
+	{0xFF00, 0xFF60},   // 전각
+	{0xFFE0, 0xFFE6},   // 전각 기호
+	{0x1F004, 0x1F004}, // 🀄
+	{0x1F0CF, 0x1F0CF},
+	{0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A},
+	{0x1F200, 0x1F251},
+	{0x1F300, 0x1F64F}, // 날씨(🌧️ 등), 표정
+	{0x1F680, 0x1F6FF}, // 교통, 지도
+	{0x1F7E0, 0x1F7EB},
+	{0x1F900, 0x1F9FF},
+	{0x1FA70, 0x1FAFF},
+	{0x20000, 0x3FFFD}, // CJK 확장 B 이후
+}
+
+// padRight는 표시 폭 기준으로 오른쪽을 공백으로 채운다.
+func padRight(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return s + strings.Repeat(" ", n)
+	}
+	return s
+}
+
+// padLeft는 표시 폭 기준으로 왼쪽을 공백으로 채운다.
+func padLeft(s string, width int) string {
+	if n := width - displayWidth(s); n > 0 {
+		return strings.Repeat(" ", n) + s
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package main
+
+import "testing"
+
+func TestDisplayWidth(t *testing.T) {
+	cases := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"PM2.5", 5},
+		{"맑음", 4},
+		{"서울 12.3°C", 11},
+		{"東京", 4},
+		{"ｗｉｄｅ", 8}, // 전각 라틴
+		{"㎍/m³", 5},
+		{"\u1112\u1161\u11AB", 2}, // 풀어 쓴 한글 "한" (초성+중성+종성)
+		{"☀", 1},                  // VS16 없으면 텍스트 표현
+		{"☀️", 2},                 // U+2600 U+FE0F
+		{"⛅", 2},
+		{"🌧️", 2}, // 원래 2칸인 이모지에 VS16
+		{"❄️ 눈", 5},
+		{"👍🏽", 2},      // 피부색 modifier
+		{"👨‍👩‍👧", 2},   // ZWJ 가족
+		{"🏳️‍🌈", 2},    // VS16 + ZWJ
+		{"🇰🇷", 2},      // 국기 (regional indicator 두 개)
+		{"e\u0301", 1}, // 결합 악센트
+		{"Cafe\u0301 ☕", 7},
+		{"a\u200Bb", 2}, // zero width space (Cf)
+		{"\x1b", 0},
+	}
+	for _, c := range cases {
+		if got := displayWidth(c.s); got != c.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", c.s, got, c.want)
+		}
+	}
+}
+
+func TestPadWidth(t *testing.T) {
+	cases := []struct {
+		s           string
+		width       int
+		right, left string
+	}{
+		{"맑음", 6, "맑음  ", "  맑음"},
+		{"☀️", 3, "☀️ ", " ☀️"},
+		{"Seoul", 3, "Seoul", "Seoul"}, // 넘치면 자르지 않는다.
+	}
+	for _, c := range cases {
+		if got := padRight(c.s, c.width); got != c.right {
+			t.Errorf("padRight(%q, %d) = %q, want %q", c.s, c.width, got, c.right)
+		}
+		if got := padLeft(c.s, c.width); got != c.left {
+			t.Errorf("padLeft(%q, %d) = %q, want %q", c.s, c.width, got, c.left)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import "fmt"
+
+// ---------- Wind ----------
+// Beaufort 단계별 상한 (km/h, 미만)
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+var beaufortNamesKR = []string{
+	"고요", "실바람", "남실바람", "산들바람", "건들바람", "흔들바람", "된바람",
+	"센바람", "큰바람", "큰센바람", "노대바람", "왕바람", "싹쓸바람",
+}
+
+var beaufortNamesEN = []string{
+	"Calm", "Light air", "Light breeze", "Gentle breeze", "Moderate breeze", "Fresh breeze", "Strong breeze",
+	"Near gale", "Gale", "Strong gale", "Storm", "Violent storm", "Hurricane force",
+}
+
+// beaufort는 풍속(km/h)을 Beaufort 단계와 한국어 이름으로 바꾼다.
+func beaufort(speedKmh float64) (int, string) {
+	for i, limit := range beaufortLimits {
+		if speedKmh < limit {
+			return i, beaufortNamesKR[i]
+		}
+	}
+	return 12, beaufortNamesKR[12]
+}
+
+func beaufortNameEN(force int) string {
+	if force < 0 || force >= len(beaufortNamesEN) {
+		return ""
+	}
+	return beaufortNamesEN[force]
+}
+
+func mphToKmh(mph float64) float64 {
+	return mph * 1.609344
+}
+
+// windKmh는 --unit에 따라 받은 풍속을 km/h로 맞춘다.
+func windKmh(speed float64, opts Options) float64 {
+	if opts.imperial() {
+		return mphToKmh(speed)
+	}
+	return speed
+}
+
+func windSymbol(opts Options) string {
+	if opts.imperial() {
+		return "mph"
+	}
+	return "km/h"
+}
+
+func printWind(w Current, opts Options) {
+	fmt.Println(opts.label("wind") + " " + windText(w, opts))
+}
+
+// windText는 --wind-scale 에 맞춘 풍속 표시다. 값이 없으면 "--" 이다. Beaufort 이름은 --lang 을 따른다.
+func windText(w Current, opts Options) string {
+	if w.WindSpeed == nil {
+		return "--"
+	}
+
+	if opts.WindScale == "beaufort" {
+		force, name := beaufort(windKmh(*w.WindSpeed, opts))
+		return fmt.Sprintf("%d (%s)", force, opts.localizedInline(name, beaufortNameEN(force)))
+	}
+	return opts.Precision.format("wind", *w.WindSpeed) + windSymbol(opts)
+}
@@ -0,0 +1,48 @@
+package main
+
+import "testing"
+
+// 각 단계의 상한은 "미만"이다. 상한 바로 아래는 그 단계, 상한 값은 다음 단계다.
+func TestBeaufortBoundaries(t *testing.T) {
+	for i, limit := range beaufortLimits {
+		if got, name := beaufort(limit - 0.01); got != i || name != beaufortNamesKR[i] {
+			t.Errorf("beaufort(%v) = %d %s, want %d %s", limit-0.01, got, name, i, beaufortNamesKR[i])
+		}
+		if got, _ := beaufort(limit); got != i+1 {
+			t.Errorf("beaufort(%v) = %d, want %d", limit, got, i+1)
+		}
+	}
+	if got, _ := beaufort(0); got != 0 {
+		t.Errorf("beaufort(0) = %d, want 0", got)
+	}
+	if got, name := beaufort(300); got != 12 || name != "싹쓸바람" {
+		t.Errorf("beaufort(300) = %d %s, want 12 싹쓸바람", got, name)
+	}
+}
+
+func TestWindTextBeaufortLang(t *testing.T) {
+	w := Current{WindSpeed: ptr(25.0)}
+	tests := []struct {
+		lang string
+		dual bool
+		want string
+	}{
+		{"ko", false, "4 (건들바람)"},
+		{"en", false, "4 (Moderate breeze)"},
+		{"ko", true, "4 (건들바람/Moderate breeze)"},
+	}
+	for _, tt := range tests {
+		opts := testOptions()
+		opts.WindScale, opts.Lang, opts.DualLang = "beaufort", tt.lang, tt.dual
+		if got := windText(w, opts); got != tt.want {
+			t.Errorf("windText(lang=%s dual=%v) = %q, want %q", tt.lang, tt.dual, got, tt.want)
+		}
+	}
+
+	// mph 로 받은 값도 km/h 경계로 본다. (15.6mph ≈ 25.1km/h)
+	opts := testOptions()
+	opts.WindScale, opts.Unit = "beaufort", "f"
+	if got := windText(Current{WindSpeed: ptr(15.6)}, opts); got != "4 (건들바람)" {
+		t.Errorf("windText(15.6mph) = %q, want 4 (건들바람)", got)
+	}
+}
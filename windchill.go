@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// ---------- Wind chill ----------
+// 풍속냉각 (캐나다/미국 공동 2001년 식)은 기온 10°C 이하, 풍속 4.8km/h 초과에서만 정의된다.
+const (
+	windChillMaxTempC = 10
+	windChillMinWind  = 4.8
+)
+
+func windChillApplies(tempC, windKmh float64) bool {
+	return tempC <= windChillMaxTempC && windKmh > windChillMinWind
+}
+
+// windChill은 체감 온도 (°C)다. 결과가 기온보다 높게 나오면 기온으로 자른다.
+func windChill(tempC, windKmh float64) float64 {
+	v := math.Pow(windKmh, 0.16)
+	wc := 13.12 + 0.6215*tempC - 11.37*v + 0.3965*tempC*v
+	return math.Min(wc, tempC)
+}
+
+// windChillText는 "체감(풍속냉각) -8.3°C" 줄이다. 해당 조건이 아니면 "" 이다.
+func windChillText(w Current, opts Options) string {
+	if w.WindSpeed == nil {
+		return ""
+	}
+	tempC := float64(opts.celsius(w.Temperature2m))
+	kmh := windKmh(*w.WindSpeed, opts)
+	if !windChillApplies(tempC, kmh) {
+		return ""
+	}
+
+	wc := windChill(tempC, kmh)
+	if opts.imperial() {
+		wc = wc*9/5 + 32
+	}
+	return fmt.Sprintf("체감(풍속냉각) %s%s", opts.Precision.format("temp", wc), tempSymbol(opts))
+}
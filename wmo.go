@@ -0,0 +1,92 @@
+package main
+
+// ---------- WMO weather codes ----------
+// wmoCodes는 Open-Meteo weather_code 로 올 수 있는 WMO 코드의 표다.
+// 아이콘, 라벨 (iconForCode, iconForCodeASCII, conditionKR, conditionEN), 심각도 (codeSeverity),
+// 강수 종류 (isRainCode, isSnowCode)를 모두 여기서 읽는다. 코드를 더하면 이 표만 고친다.
+type wmoCode struct {
+	emoji, ascii string // 아이콘
+	label, en    string // 한국어, 영어 라벨
+
+	// night가 있으면 밤에 아이콘과 라벨을 바꾼다. (맑음, 흐림)
+	night *wmoNight
+
+	// severity는 codeSeverity 순서다. (맑음 < 흐림 < 안개 < 이슬비 < 비 < 어는 비 < 눈 < 뇌우)
+	severity int
+	precip   precipKind
+}
+
+type wmoNight struct {
+	emoji, ascii, label string
+}
+
+// precipKind는 --advice 의 우산/눈 조언에 쓰는 강수 종류다.
+type precipKind int
+
+const (
+	precipNone precipKind = iota
+	precipRain            // 이슬비, 비, 소나기, 뇌우 (어는 것 포함)
+	precipSnow            // 눈, 싸락눈, 소낙눈
+)
+
+var (
+	clearNight  = &wmoNight{"🌙", "[)]", "맑은 밤"}
+	cloudyNight = &wmoNight{"☁️", "[~]", "흐린 밤"}
+)
+
+var wmoCodes = map[int]wmoCode{
+	0:  {"☀️", "[*]", "맑음", "Clear", clearNight, 0, precipNone},
+	1:  {"☁️", "[~]", "흐림", "Cloudy", cloudyNight, 1, precipNone}, // 대체로 맑음
+	2:  {"☁️", "[~]", "흐림", "Cloudy", cloudyNight, 2, precipNone}, // 구름 조금
+	3:  {"☁️", "[~]", "흐림", "Cloudy", cloudyNight, 3, precipNone},
+	45: {"🌫️", "[=]", "안개", "Fog", nil, 4, precipNone},
+	48: {"🌫️", "[=]", "안개", "Fog", nil, 4, precipNone},
+	51: {"🌦️", "[,]", "이슬비", "Drizzle", nil, 5, precipRain},
+	53: {"🌦️", "[,]", "이슬비", "Drizzle", nil, 6, precipRain},
+	55: {"🌦️", "[,]", "이슬비", "Drizzle", nil, 7, precipRain},
+	56: {"🌧️", "[,]", "어는 이슬비", "Freezing drizzle", nil, 8, precipRain},
+	57: {"🌧️", "[,]", "어는 이슬비", "Freezing drizzle", nil, 8, precipRain},
+	61: {"🌧️", "[/]", "비", "Rain", nil, 9, precipRain},
+	63: {"🌧️", "[/]", "비", "Rain", nil, 10, precipRain},
+	65: {"🌧️", "[/]", "비", "Rain", nil, 11, precipRain},
+	66: {"🌧️", "[/]", "어는 비", "Freezing rain", nil, 12, precipRain},
+	67: {"🌧️", "[/]", "어는 비", "Freezing rain", nil, 12, precipRain},
+	71: {"🌨️", "[#]", "눈", "Snow", nil, 13, precipSnow},
+	73: {"🌨️", "[#]", "눈", "Snow", nil, 14, precipSnow},
+	75: {"🌨️", "[#]", "눈", "Snow", nil, 15, precipSnow},
+	77: {"🌨️", "[#]", "싸락눈", "Snow grains", nil, 13, precipSnow},
+	80: {"🌦️", "[/]", "소나기", "Rain showers", nil, 9, precipRain},
+	81: {"🌦️", "[/]", "소나기", "Rain showers", nil, 10, precipRain},
+	82: {"🌦️", "[/]", "소나기", "Rain showers", nil, 11, precipRain},
+	85: {"🌨️", "[#]", "소낙눈", "Snow showers", nil, 13, precipSnow},
+	86: {"🌨️", "[#]", "소낙눈", "Snow showers", nil, 15, precipSnow},
+	95: {"⛈️", "[!]", "뇌우", "Thunderstorm", nil, 16, precipRain},
+	96: {"⛈️", "[!]", "뇌우 (우박)", "Thunderstorm with hail", nil, 17, precipRain},
+	99: {"⛈️", "[!]", "뇌우 (우박)", "Thunderstorm with hail", nil, 18, precipRain},
+}
+
+// unknownCode는 표에 없는 코드다.
+var unknownCode = wmoCode{"🌡️", "[?]", "알 수 없음", "Unknown conditions", nil, -1, precipNone}
+
+// lookupCode는 code의 표 항목이다. 없으면 unknownCode 다.
+func lookupCode(code int) wmoCode {
+	if c, ok := wmoCodes[code]; ok {
+		return c
+	}
+	return unknownCode
+}
+
+// icon은 "아이콘  라벨"이다. isNight면 밤 아이콘과 라벨을 쓴다.
+func (c wmoCode) icon(isNight, ascii bool) string {
+	icon, label := c.emoji, c.label
+	if ascii {
+		icon = c.ascii
+	}
+	if isNight && c.night != nil {
+		icon, label = c.night.emoji, c.night.label
+		if ascii {
+			icon = c.night.ascii
+		}
+	}
+	return icon + "  " + label
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 표의 모든 코드는 심각도, 한/영 라벨, 두 아이콘 세트를 모두 가진다.
+func TestWMOCodesCovered(t *testing.T) {
+	for code := range wmoCodes {
+		if codeSeverity(code) < 0 {
+			t.Errorf("%d: no severity", code)
+		}
+		if conditionKR(code) == unknownCode.label || conditionEN(code) == unknownCode.en {
+			t.Errorf("%d: no label (%s, %s)", code, conditionKR(code), conditionEN(code))
+		}
+		for _, night := range []bool{false, true} {
+			emoji, ascii := iconForCode(code, night), iconForCodeASCII(code, night)
+			if strings.HasPrefix(emoji, unknownCode.emoji) || strings.HasPrefix(ascii, unknownCode.ascii) {
+				t.Errorf("%d (night=%v): no icon (%q, %q)", code, night, emoji, ascii)
+			}
+		}
+	}
+	if codeSeverity(42) != -1 || conditionEN(42) != "Unknown conditions" || iconForCodeASCII(42, false) != "[?]  알 수 없음" {
+		t.Error("unmapped code 42 not reported as unknown")
+	}
+}
+
+// 강수 코드는 (안개 다음, 51 이상) 모두 비 또는 눈 조언을 낸다.
+func TestWMOPrecipKinds(t *testing.T) {
+	for code := range wmoCodes {
+		rain, snow := isRainCode(code), isSnowCode(code)
+		if rain && snow {
+			t.Errorf("%d: both rain and snow", code)
+		}
+		if code >= 51 && !rain && !snow {
+			t.Errorf("%d: precipitation code without rain/snow advice", code)
+		}
+		if code < 51 && (rain || snow) {
+			t.Errorf("%d: dry code marked as precipitation", code)
+		}
+	}
+
+	for _, tt := range []struct {
+		code int
+		want adviceKind
+	}{
+		{56, adviceRainKind}, {67, adviceRainKind}, {80, adviceRainKind}, {82, adviceRainKind}, {96, adviceRainKind}, {99, adviceRainKind},
+		{77, adviceSnowKind}, {85, adviceSnowKind}, {86, adviceSnowKind},
+	} {
+		w := Current{WeatherCode: tt.code, ApparentTemperature: 15, PrecipProbability: ptr(0.0)}
+		if kinds := adviceKinds(w, AirQualityCurrent{}, 0); !slices.Contains(kinds, tt.want) {
+			t.Errorf("code %d: advice kinds %v, want %v", tt.code, kinds, tt.want)
+		}
+	}
+}